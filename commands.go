@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/patrickmn/go-cache"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/history"
+	"weatherbot/internal/logger"
+	"weatherbot/internal/scheduler"
+	"weatherbot/internal/telegram"
+	"weatherbot/internal/weather/providers"
+)
+
+// runSubcommand executes command given in command line and returns exit code
+func runSubcommand(args []string, crontabFile string) int {
+	switch args[0] {
+	case "crontab":
+		return crontabCommand(args[1:], crontabFile)
+	case "history":
+		return historyCommand(args[1:])
+	case "run":
+		return runCommand(args[1:], crontabFile)
+	case "stop":
+		return stopCommand()
+	case "status":
+		return statusCommand()
+	case "reload":
+		return reloadCommand()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", args[0])
+		flag.Usage()
+		return 2
+	}
+}
+
+// crontabCommand "crontab check [-n N] [file]"
+func crontabCommand(args []string, crontabFile string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: crontab check [-n N] [file]")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("crontab check", flag.ContinueOnError)
+	next := fs.Int("n", 3, "Number of next run times to show for each task")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		crontabFile = fs.Arg(0)
+	}
+
+	if err := scheduler.Check(os.Stdout, crontabFile, *next); err != nil {
+		var parseErrors scheduler.ParseErrors
+		if !errors.As(err, &parseErrors) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// runCommand "run [-dry-run] [-out dir] <command> [args]" runs command right away
+// with dry run messages are written to directory instead of sending to telegram and run is not saved to history
+func runCommand(args []string, crontabFile string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Write images and weather data to directory instead of sending to Telegram")
+	outputDir := fs.String("out", "output", "Directory for dry run output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: run [-dry-run] [-out dir] <command> [args]")
+		return 2
+	}
+
+	config.IniConfig()
+	logger.InitLogger(true)
+	log := logger.Logger()
+	initLocale()
+
+	if err := providers.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Wrong weather provider: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	app := &app.AppContext{
+		Cache:   cache.New(cache.NoExpiration, cache.NoExpiration),
+		Crontab: crontabFile,
+		ChatID:  config.GetTelegramChatId(),
+		Logger:  log,
+		Context: ctx,
+		Cancel:  cancel,
+	}
+	if *dryRun {
+		app.OutputDir = *outputDir
+	} else {
+		bot, err := telegram.NewTelegramBot(config.GetTelegramToken())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create telegram bot: %v\n", err)
+			return 1
+		}
+		app.TelegramBot = bot
+		store := newStore()
+		app.History = newHistory(store)
+		app.Alerts = newAlerts(store)
+	}
+
+	if err := scheduler.RunOnce(app, fs.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *dryRun {
+		fmt.Printf("Output is written to %s\n", *outputDir)
+	}
+	return 0
+}
+
+// historyCommand "history [-n N] [-command name] [-status success|failed] [-city name] [-since duration]"
+func historyCommand(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	limit := fs.Int("n", 20, "Number of runs to show")
+	cmd := fs.String("command", "", "Show only runs of given command")
+	status := fs.String("status", "", "Show only runs with given status (success or failed)")
+	city := fs.String("city", "", "Show only runs for given city")
+	since := fs.Duration("since", 0, "Show only runs started within given duration, e.g. 24h")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	config.IniConfig()
+	filter := history.Filter{
+		Command: *cmd,
+		Status:  *status,
+		Target:  *city,
+		Limit:   *limit,
+	}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+
+	records, err := newHistory(newStore()).List(filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	printHistory(os.Stdout, records)
+	return 0
+}
+
+func printHistory(w io.Writer, records []*history.Record) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTART\tDURATION\tSTATUS\tCOMMAND")
+	for _, r := range records {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", r.ID, r.Start.Format(time.DateTime), r.End.Sub(r.Start).Round(time.Millisecond), r.Status, r.Command)
+		if r.Error != "" {
+			fmt.Fprintf(tw, "\t\t\t\terror: %s\n", r.Error)
+		}
+		for _, outcome := range r.Outcomes {
+			result := "ok"
+			if outcome.Error != "" {
+				result = "error: " + outcome.Error
+			}
+			if len(outcome.MessageIDs) > 0 {
+				result += fmt.Sprintf(" (message %v)", outcome.MessageIDs)
+			}
+			fmt.Fprintf(tw, "\t\t\t\t%s: %s\n", outcome.Target, result)
+		}
+	}
+	tw.Flush()
+}
@@ -2,7 +2,6 @@ package config
 
 import (
 	"github.com/spf13/viper"
-	"strings"
 	"weatherbot/internal/logger"
 )
 
@@ -21,10 +20,8 @@ func GetConfigValue(key string) string {
 	return viper.GetString(key)
 }
 
-func GetApiKey() string {
-	provider := GetConfigValue("WEATHER_PROVIDER")
-	key := strings.ToUpper(provider) + "_API_KEY"
-	return GetConfigValue(key)
+func GetConfigInt(key string) int {
+	return viper.GetInt(key)
 }
 
 func GetTelegramToken() string {
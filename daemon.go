@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"github.com/sevlyar/go-daemon"
+	"os"
+	"syscall"
+	"text/tabwriter"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/scheduler"
+)
+
+const defaultPidFile = "weatherbot.pid"
+const daemonLogFile = "log/daemon.log"
+
+// stopTimeout how long "stop" waits for daemon exit. daemon itself waits for tasks up to SHUTDOWN_TIMEOUT
+const stopTimeout = 60 * time.Second
+
+// statusTimeout how long "status" waits for status file from daemon
+const statusTimeout = 3 * time.Second
+
+// newDaemonContext returns daemon context. pid file is taken from PID_FILE config
+// stdout and stderr of daemon (e.g. panics) are written to log/daemon.log
+func newDaemonContext() *daemon.Context {
+	pidFile := config.GetConfigValue("PID_FILE")
+	if pidFile == "" {
+		pidFile = defaultPidFile
+	}
+	if err := os.MkdirAll("log", 0755); err != nil {
+		fmt.Printf("Failed to create log directory: %v\n", err)
+	}
+	return &daemon.Context{
+		PidFileName: pidFile,
+		PidFilePerm: 0644,
+		LogFileName: daemonLogFile,
+		LogFilePerm: 0640,
+		WorkDir:     "./",
+		Umask:       027,
+		Args:        os.Args,
+	}
+}
+
+// startDaemon starts daemon process. child process is returned in parent, nil in daemon itself
+// pid file is locked by running daemon, so second (standby) instance must have its own PID_FILE
+func startDaemon() (*daemon.Context, *os.Process, error) {
+	cntxt := newDaemonContext()
+	if !daemon.WasReborn() {
+		if process, err := cntxt.Search(); err == nil && process != nil {
+			return nil, nil, fmt.Errorf("daemon with pid file %s is already running (PID %d). set other PID_FILE to start standby instance", cntxt.PidFileName, process.Pid)
+		}
+	}
+	child, err := cntxt.Reborn()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cntxt, child, nil
+}
+
+// findDaemon returns running daemon by pid file
+func findDaemon() (*os.Process, error) {
+	config.IniConfig()
+	cntxt := newDaemonContext()
+	process, err := cntxt.Search()
+	if err != nil || process == nil {
+		return nil, fmt.Errorf("daemon is not running (pid file %s)", cntxt.PidFileName)
+	}
+	return process, nil
+}
+
+// stopCommand sends SIGTERM to daemon and waits for its exit
+func stopCommand() int {
+	process, err := findDaemon()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		fmt.Printf("Failed to stop daemon: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Stopping daemon (PID %d)...\n", process.Pid)
+	deadline := time.Now().Add(stopTimeout)
+	for time.Now().Before(deadline) {
+		if process.Signal(syscall.Signal(0)) != nil {
+			fmt.Println("Daemon stopped")
+			return 0
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	fmt.Println("Daemon is still running")
+	return 1
+}
+
+// reloadCommand sends SIGHUP to daemon, so it rereads crontab
+func reloadCommand() int {
+	process, err := findDaemon()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		fmt.Printf("Failed to reload daemon: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Reload signal sent to daemon (PID %d)\n", process.Pid)
+	return 0
+}
+
+// statusCommand asks daemon to write status file (SIGUSR1) and prints it
+func statusCommand() int {
+	process, err := findDaemon()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Printf("Daemon is running. PID: %d\n", process.Pid)
+
+	requested := time.Now()
+	if err := process.Signal(syscall.SIGUSR1); err != nil {
+		fmt.Printf("Failed to request status: %v\n", err)
+		return 1
+	}
+
+	var status *scheduler.Status
+	for deadline := requested.Add(statusTimeout); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		status, err = scheduler.ReadStatus(scheduler.StatusFile(process.Pid))
+		if err == nil && !status.Updated.Before(requested) {
+			break
+		}
+	}
+	if status == nil {
+		fmt.Printf("Failed to read status: %v\n", err)
+		return 1
+	}
+	if status.Updated.Before(requested) {
+		fmt.Printf("Status is not updated by daemon, showing status from %s\n", status.Updated.Format(time.DateTime))
+	}
+	printStatus(status)
+	return 0
+}
+
+func printStatus(status *scheduler.Status) {
+	fmt.Printf("Crontab: %s\n", status.Crontab)
+	if status.Holder != nil {
+		fmt.Printf("Lock: held by %s\n", status.Holder)
+	}
+	if status.Standby {
+		fmt.Println("Instance is in standby mode and waits for the lock")
+		return
+	}
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSCHEDULE\tNEXT RUN\tJITTER\tCOMMAND")
+	for _, task := range status.Tasks {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", task.Line, task.Schedule, task.Next.Format(time.DateTime), formatDelay(task.Jitter), task.Command)
+	}
+	tw.Flush()
+
+	fmt.Println()
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STARTED\tDELAY\tSTATUS\tCOMMAND")
+	for _, run := range status.Running {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", run.Start.Format(time.DateTime), formatDelay(run.Delay), run.Status, run.Command)
+	}
+	for _, run := range status.LastRuns {
+		result := string(run.Status)
+		if run.Error != "" {
+			result += ": " + run.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", run.Start.Format(time.DateTime), formatDelay(run.Delay), result, run.Command)
+	}
+	tw.Flush()
+}
+
+func formatDelay(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.String()
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/patrickmn/go-cache"
+	"github.com/sevlyar/go-daemon"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/scheduler"
+)
+
+// daemonHelperEnv is set for process started by TestTwoDaemons
+const daemonHelperEnv = "WEATHERBOT_DAEMON_HELPER"
+
+// TestDaemonHelper is not a real test. it is run as separate process by TestTwoDaemons
+// and starts daemon the same way as main, with scheduler of empty crontab
+func TestDaemonHelper(t *testing.T) {
+	if os.Getenv(daemonHelperEnv) != "1" {
+		return
+	}
+	config.IniConfig()
+
+	signals := make(chan os.Signal, 4)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1)
+
+	cntxt, child, err := startDaemon()
+	if err != nil {
+		fmt.Printf("Failed to start daemon: %v\n", err)
+		os.Exit(1)
+	}
+	if child != nil {
+		os.Exit(0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(&app.AppContext{
+		Cache:   cache.New(cache.NoExpiration, cache.NoExpiration),
+		Crontab: "crontab",
+		Logger:  logrus.New(),
+		Context: ctx,
+		Cancel:  cancel,
+	}, signals)
+	cntxt.Release()
+	os.Exit(0)
+}
+
+func TestTwoDaemons(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts daemon processes")
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	env := "LOCK_FILE=data/weatherbot.lock\nSTATUS_FILE=data/status.json\nSHUTDOWN_TIMEOUT=1s\n"
+	if err := os.WriteFile(filepath.Join(dir, "config", ".env"), []byte(env), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "crontab"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := func(pidFile string) (string, error) {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDaemonHelper$")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), daemonHelperEnv+"=1", "PID_FILE="+pidFile)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	// pid of daemon is read from pid file, process is stopped at the end of test
+	daemonPid := func(pidFile string) int {
+		var pid int
+		waitFor(t, "pid file "+pidFile, func() bool {
+			var err error
+			pid, err = daemon.ReadPidFile(filepath.Join(dir, pidFile))
+			return err == nil
+		})
+		t.Cleanup(func() { syscall.Kill(pid, syscall.SIGKILL) })
+		return pid
+	}
+	status := func(pid int, standby bool) *scheduler.Status {
+		path := filepath.Join(dir, "data", fmt.Sprintf("status.%d.json", pid))
+		var res *scheduler.Status
+		waitFor(t, "status file of "+fmt.Sprint(pid), func() bool {
+			var err error
+			res, err = scheduler.ReadStatus(path)
+			return err == nil && res.Standby == standby
+		})
+		return res
+	}
+
+	if out, err := start("leader.pid"); err != nil {
+		t.Fatalf("failed to start leader: %v\n%s", err, out)
+	}
+	leader := daemonPid("leader.pid")
+	status(leader, false)
+
+	out, err := start("leader.pid")
+	if err == nil || !strings.Contains(out, "already running") {
+		t.Errorf("expected error for the same pid file, got %v\n%s", err, out)
+	}
+
+	if out, err := start("standby.pid"); err != nil {
+		t.Fatalf("failed to start standby: %v\n%s", err, out)
+	}
+	standby := daemonPid("standby.pid")
+	if err := syscall.Kill(standby, syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	if res := status(standby, true); res.Holder == nil || res.Holder.PID != leader {
+		t.Errorf("expected lock held by leader %d, got %v", leader, res.Holder)
+	}
+	if err := syscall.Kill(leader, syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	if res := status(leader, false); res.PID != leader {
+		t.Errorf("expected status of leader %d, got %d", leader, res.PID)
+	}
+
+	for _, pid := range []int{standby, leader} {
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"standby.pid", "leader.pid", fmt.Sprintf("data/status.%d.json", standby), fmt.Sprintf("data/status.%d.json", leader)} {
+		path := filepath.Join(dir, name)
+		waitFor(t, "removal of "+name, func() bool {
+			_, err := os.Stat(path)
+			return os.IsNotExist(err)
+		})
+	}
+}
+
+// waitFor waits up to 10 seconds until condition is true
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatalf("timeout waiting for %s", what)
+}
@@ -12,6 +12,7 @@ require (
 	github.com/sevlyar/go-daemon v0.1.6
 	github.com/sirupsen/logrus v1.9.3
 	github.com/spf13/viper v1.19.0
+	go.etcd.io/bbolt v1.3.10
 	golang.org/x/net v0.23.0
 	golang.org/x/text v0.14.0
 )
@@ -4,24 +4,57 @@ import (
 	"encoding/json"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
+	"path/filepath"
+	"sync"
 	"weatherbot/internal/logger"
 )
 
+const localesDir = "i18n/locales/"
+
 var bundle *i18n.Bundle
 var localizer *i18n.Localizer
 
+var mu sync.Mutex
+
+// localizers contains localizer for each used locale
+var localizers = map[string]*i18n.Localizer{}
+
 func Initialize(defaultLanguage string, locales ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	bundle = i18n.NewBundle(language.Make(defaultLanguage))
 	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	localizers = map[string]*i18n.Localizer{}
 
+	loadLocales()
 	for _, locale := range locales {
-		_, err := bundle.LoadMessageFile("i18n/locales/" + locale + ".json")
-		if err != nil {
+		newLocalizer(locale)
+	}
+
+	localizer = i18n.NewLocalizer(bundle, defaultLanguage)
+}
+
+// loadLocales load all locale files. bundle is not safe to modify while localizers read it,
+// so files are loaded once on initialization instead of the first use of locale
+func loadLocales() {
+	files, err := filepath.Glob(localesDir + "*.json")
+	if err != nil {
+		logger.Logger().Println("Error reading locales:", err)
+		return
+	}
+	for _, fileName := range files {
+		if _, err := bundle.LoadMessageFile(fileName); err != nil {
 			logger.Logger().Println("Error loading locale file:", err)
 		}
 	}
+}
 
-	localizer = i18n.NewLocalizer(bundle, defaultLanguage)
+// newLocalizer create localizer for locale
+func newLocalizer(locale string) *i18n.Localizer {
+	l := i18n.NewLocalizer(bundle, locale)
+	localizers[locale] = l
+	return l
 }
 
 // SetLocale set current locale
@@ -31,7 +64,34 @@ func SetLocale(locale string) {
 
 // Translate the message. if no translation then returns original text
 func Translate(messageID string) string {
-	translated, err := localizer.Localize(&i18n.LocalizeConfig{MessageID: messageID})
+	return localize(localizer, messageID)
+}
+
+// TranslateTo translate the message to given locale. localizer is created at first call
+func TranslateTo(locale, messageID string) string {
+	if locale == "" {
+		return Translate(messageID)
+	}
+
+	mu.Lock()
+	if bundle == nil {
+		mu.Unlock()
+		return messageID
+	}
+	l, found := localizers[locale]
+	if !found {
+		l = newLocalizer(locale)
+	}
+	mu.Unlock()
+
+	return localize(l, messageID)
+}
+
+func localize(l *i18n.Localizer, messageID string) string {
+	if l == nil {
+		return messageID
+	}
+	translated, err := l.Localize(&i18n.LocalizeConfig{MessageID: messageID})
 	if err != nil {
 		return messageID
 	}
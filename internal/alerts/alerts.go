@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	bolt "go.etcd.io/bbolt"
+	"time"
+	"weatherbot/internal/storage"
+	"weatherbot/internal/weather"
+)
+
+const bucketAlerts = "alerts"
+
+// Kind kind of alert change
+type Kind string
+
+const (
+	// KindNew alert is not seen before
+	KindNew Kind = "new"
+	// KindExtended validity window of seen alert is changed
+	KindExtended Kind = "extended"
+	// KindCancelled seen alert is cancelled or removed by provider before its end
+	KindCancelled Kind = "cancelled"
+	// KindExpired seen alert is ended. it is removed from store without notification
+	KindExpired Kind = "expired"
+)
+
+// Change change of alert since the last check. Previous is seen alert
+type Change struct {
+	Kind     Kind
+	Alert    weather.Alert
+	Previous *weather.Alert
+}
+
+// Seen alert which notification is sent
+type Seen struct {
+	Alert     weather.Alert `json:"alert"`
+	MessageID int           `json:"message_id,omitempty"`
+	Notified  time.Time     `json:"notified"`
+}
+
+// now current time. it is replaced in tests
+var now = time.Now
+
+// Store persistent store of seen alerts
+// alerts are keyed by provider, city and provider alert id, validity window is kept in value
+type Store struct {
+	store *storage.Store
+}
+
+// New returns alerts store which uses given storage
+func New(store *storage.Store) *Store {
+	return &Store{store: store}
+}
+
+// Changes compares active alerts of city from provider with seen ones
+// seen alerts of other providers are not compared, so fallback to another provider does not cancel them
+// it is safe to call it for nil store, then all active alerts are new
+func (s *Store) Changes(provider, city string, active []weather.Alert) ([]Change, error) {
+	seen, err := s.seen(provider, city)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	currentTime := now()
+	for _, alert := range active {
+		prev, found := seen[alert.ID]
+		delete(seen, alert.ID)
+		switch {
+		case alert.Cancelled:
+			if found {
+				changes = append(changes, Change{Kind: KindCancelled, Alert: alert, Previous: &prev.Alert})
+			}
+		case !alert.End.IsZero() && alert.End.Before(currentTime):
+			if found {
+				changes = append(changes, Change{Kind: KindExpired, Alert: alert, Previous: &prev.Alert})
+			}
+		case !found:
+			changes = append(changes, Change{Kind: KindNew, Alert: alert})
+		case !alert.Start.Equal(prev.Alert.Start) || !alert.End.Equal(prev.Alert.End):
+			changes = append(changes, Change{Kind: KindExtended, Alert: alert, Previous: &prev.Alert})
+		}
+	}
+	// seen alerts which are not active anymore
+	for _, prev := range seen {
+		kind := KindExpired
+		if prev.Alert.End.IsZero() || prev.Alert.End.After(currentTime) {
+			kind = KindCancelled
+		}
+		changes = append(changes, Change{Kind: kind, Alert: prev.Alert, Previous: &prev.Alert})
+	}
+	return changes, nil
+}
+
+// Apply saves change after its notification is sent. it is safe to call it for nil store
+func (s *Store) Apply(change Change, messageID int) error {
+	if s == nil {
+		return nil
+	}
+	return s.store.Update(bucketAlerts, func(b *bolt.Bucket) error {
+		k := key(change.Alert.Provider, change.Alert.City, change.Alert.ID)
+		if change.Kind == KindCancelled || change.Kind == KindExpired {
+			return b.Delete(k)
+		}
+		data, err := json.Marshal(Seen{Alert: change.Alert, MessageID: messageID, Notified: now()})
+		if err != nil {
+			return err
+		}
+		return b.Put(k, data)
+	})
+}
+
+// seen returns seen alerts of city from provider by alert id
+func (s *Store) seen(provider, city string) (map[string]Seen, error) {
+	result := map[string]Seen{}
+	if s == nil {
+		return result, nil
+	}
+	prefix := key(provider, city, "")
+	err := s.store.View(bucketAlerts, func(b *bolt.Bucket) error {
+		c := b.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var item Seen
+			if err := json.Unmarshal(v, &item); err != nil {
+				return err
+			}
+			result[item.Alert.ID] = item
+		}
+		return nil
+	})
+	return result, err
+}
+
+func key(provider, city, id string) []byte {
+	return []byte(provider + "/" + city + "/" + id)
+}
@@ -0,0 +1,95 @@
+package alerts
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	"weatherbot/internal/storage"
+	"weatherbot/internal/weather"
+)
+
+// check returns changes of active alerts and applies them
+func check(t *testing.T, s *Store, active ...weather.Alert) []Change {
+	t.Helper()
+	changes, err := s.Changes("weatherapi", "Moscow", active)
+	if err != nil {
+		t.Fatalf("Changes() error: %v", err)
+	}
+	for i, change := range changes {
+		if err := s.Apply(change, 100+i); err != nil {
+			t.Fatalf("Apply() error: %v", err)
+		}
+	}
+	return changes
+}
+
+func kinds(changes []Change) []Kind {
+	result := make([]Kind, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, change.Kind)
+	}
+	return result
+}
+
+func TestChanges(t *testing.T) {
+	current := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	s := New(storage.New(filepath.Join(t.TempDir(), "test.db")))
+	wind := weather.Alert{
+		ID: "wind", Provider: "weatherapi", City: "Moscow", Event: "Wind",
+		Start: current.Add(-time.Hour), End: current.Add(6 * time.Hour),
+	}
+	heat := weather.Alert{
+		ID: "heat", Provider: "weatherapi", City: "Moscow", Event: "Heat",
+		Start: current, End: current.Add(2 * time.Hour),
+	}
+
+	if got := kinds(check(t, s, wind)); len(got) != 1 || got[0] != KindNew {
+		t.Fatalf("expected new alert, got %v", got)
+	}
+	// the same alert is not sent again
+	if got := check(t, s, wind); len(got) != 0 {
+		t.Fatalf("expected no changes, got %v", kinds(got))
+	}
+	// alerts of other providers are not compared
+	if changes, _ := s.Changes("openweathermap", "Moscow", nil); len(changes) != 0 {
+		t.Fatalf("expected no changes for other provider, got %v", kinds(changes))
+	}
+
+	extended := wind
+	extended.End = wind.End.Add(12 * time.Hour)
+	changes := check(t, s, extended, heat)
+	if got := kinds(changes); len(got) != 2 || got[0] != KindExtended || got[1] != KindNew {
+		t.Fatalf("expected extended and new alerts, got %v", got)
+	}
+	if !changes[0].Previous.End.Equal(wind.End) {
+		t.Errorf("expected previous end %v, got %v", wind.End, changes[0].Previous.End)
+	}
+
+	// wind alert disappears before its end, heat alert is ended
+	current = current.Add(3 * time.Hour)
+	changes = check(t, s)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %v", kinds(changes))
+	}
+	for _, change := range changes {
+		want := KindCancelled
+		if change.Alert.ID == "heat" {
+			want = KindExpired
+		}
+		if change.Kind != want {
+			t.Errorf("expected %s for %s, got %s", want, change.Alert.ID, change.Kind)
+		}
+	}
+	if got := check(t, s); len(got) != 0 {
+		t.Fatalf("expected empty store, got %v", kinds(got))
+	}
+
+	// nil store reports every active alert as new
+	var empty *Store
+	if changes, err := empty.Changes("weatherapi", "Moscow", []weather.Alert{wind}); err != nil || len(changes) != 1 {
+		t.Fatalf("expected new alert from nil store, got %v, %v", kinds(changes), err)
+	}
+}
@@ -0,0 +1,62 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"weatherbot/i18n"
+)
+
+// timeLayout layout of validity time in messages
+const timeLayout = "02.01.2006 15:04"
+
+// Text returns notification text of change in given language
+func Text(change Change, lang string) string {
+	alert := change.Alert
+	var title string
+	switch change.Kind {
+	case KindExtended:
+		title = "Weather alert extended"
+		if change.Previous != nil && alert.End.Before(change.Previous.End) {
+			title = "Weather alert changed"
+		}
+	case KindCancelled:
+		title = "Weather alert cancelled"
+	default:
+		title = "Weather alert"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s\n%s", i18n.TranslateTo(lang, title), alert.Event, alert.City)
+	if change.Kind == KindCancelled {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "\n%s: %s", i18n.TranslateTo(lang, "Valid"), validity(alert.Start, alert.End))
+	if change.Kind == KindExtended {
+		return sb.String()
+	}
+	if alert.Severity != "" {
+		fmt.Fprintf(&sb, "\n%s: %s", i18n.TranslateTo(lang, "Severity"), alert.Severity)
+	}
+	if alert.Headline != "" && alert.Headline != alert.Event {
+		fmt.Fprintf(&sb, "\n\n%s", alert.Headline)
+	}
+	if alert.Description != "" {
+		fmt.Fprintf(&sb, "\n\n%s", alert.Description)
+	}
+	if alert.Sender != "" {
+		fmt.Fprintf(&sb, "\n\n%s", alert.Sender)
+	}
+	return sb.String()
+}
+
+// validity returns validity window of alert. zero time means unknown bound
+func validity(start, end time.Time) string {
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return "?"
+		}
+		return t.Local().Format(timeLayout)
+	}
+	return format(start) + " - " + format(end)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/patrickmn/go-cache"
 	"github.com/sirupsen/logrus"
+	"weatherbot/internal/alerts"
+	"weatherbot/internal/history"
 	"weatherbot/internal/telegram"
 )
 
@@ -15,4 +17,10 @@ type AppContext struct {
 	ChatID      int64
 	Logger      *logrus.Logger
 	Context     context.Context
+	Cancel      context.CancelFunc
+	History     *history.History
+	// Alerts seen weather alerts. alerts are not saved when it is nil (dry run)
+	Alerts *alerts.Store
+	// OutputDir dry run mode: messages are written to this directory instead of sending to telegram
+	OutputDir string
 }
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"weatherbot/config"
+	"weatherbot/internal/alerts"
+	"weatherbot/internal/app"
+	"weatherbot/internal/history"
+	"weatherbot/internal/telegram/message"
+	"weatherbot/internal/weather/providers"
+	"weatherbot/utils"
+)
+
+// alertsCommand checks severe weather alerts for given cities and sends new ones to telegram
+// follow-up is sent when seen alert is extended or cancelled
+// alerts --chat=-100123 --provider=weatherapi --lang=en Moscow
+type alertsCommand struct{}
+
+func init() {
+	Register(&alertsCommand{})
+}
+
+func (c *alertsCommand) Name() string {
+	return "alerts"
+}
+
+func (c *alertsCommand) Args() []Arg {
+	return []Arg{
+		{Name: "city", Type: City, Required: true, Variadic: true},
+	}
+}
+
+func (c *alertsCommand) Options() []Arg {
+	return []Arg{
+		{Name: "chat", Type: Int},
+		{Name: "provider", Check: checkProviders},
+		{Name: "lang"},
+	}
+}
+
+func (c *alertsCommand) Validate(args *Args) error {
+	return ValidateArgs(c, args)
+}
+
+func (c *alertsCommand) Run(ctx context.Context, app *app.AppContext, args *Args) error {
+	chatID := app.ChatID
+	if chat := args.Option("chat", ""); chat != "" {
+		var err error
+		if chatID, err = strconv.ParseInt(chat, 10, 64); err != nil {
+			return fmt.Errorf("wrong chat id %q: %w", chat, err)
+		}
+	}
+	chain := providers.Chain(args.Option("provider", ""))
+	lang := args.Option("lang", config.GetConfigValue("LANGUAGE"))
+
+	record := history.FromContext(ctx)
+	var errs []error
+	for _, city := range args.Values {
+		name := city
+		if cityInfo, err := utils.ParseCity(city); err == nil {
+			name = cityInfo.Name
+		}
+		messageIDs, err := notifyAlerts(ctx, app, city, name, chain, chatID, lang)
+		record.AddOutcome(name, err, messageIDs...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyAlerts sends notifications about changes of alerts of city. returns ids of sent messages
+// change is saved only after its notification is sent, so failed one is sent on the next run
+func notifyAlerts(ctx context.Context, app *app.AppContext, city, name string, chain []string, chatID int64, lang string) ([]int, error) {
+	active, provider, err := providers.GetAlerts(ctx, app, city, chain, lang)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := app.Alerts.Changes(provider, name, active)
+	if err != nil {
+		return nil, err
+	}
+
+	var messageIDs []int
+	for _, change := range changes {
+		var messageID int
+		if change.Kind != alerts.KindExpired {
+			text := alerts.Text(change, lang)
+			if runes := []rune(text); len(runes) > message.MaxTextLength {
+				text = string(runes[:message.MaxTextLength])
+			}
+			if messageID, err = message.SendText(ctx, app, chatID, text); err != nil {
+				return messageIDs, err
+			}
+			messageIDs = append(messageIDs, messageID)
+			app.Logger.Printf("Alert %s %s for city %s from %s", change.Alert.Event, change.Kind, name, provider)
+		}
+		if err := app.Alerts.Apply(change, messageID); err != nil {
+			return messageIDs, err
+		}
+	}
+	return messageIDs, nil
+}
@@ -0,0 +1,236 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"weatherbot/internal/app"
+	"weatherbot/utils"
+)
+
+// Command is a command which can be scheduled in crontab
+type Command interface {
+	// Name returns command name as it is written in crontab
+	Name() string
+	// Args returns schema of positional arguments
+	Args() []Arg
+	// Options returns schema of named options (--name=value)
+	Options() []Arg
+	// Validate checks arguments. it is called when crontab is loaded
+	Validate(args *Args) error
+	// Run executes command
+	Run(ctx context.Context, app *app.AppContext, args *Args) error
+}
+
+// OptionsFirstCommand is implemented by commands whose options are written before positional arguments only
+// tokens after the first positional argument are positional too, so options of external program
+// are passed to it as is: exec --timeout=30s ls --all
+type OptionsFirstCommand interface {
+	OptionsFirst()
+}
+
+// Args command arguments parsed from crontab line
+// Values are positional arguments, Options are named ones: --chat=-100123
+type Args struct {
+	Values  []string
+	Options map[string]string
+}
+
+// Option returns value of named option or def if option is not set
+func (a *Args) Option(name, def string) string {
+	if value, found := a.Options[name]; found && value != "" {
+		return value
+	}
+	return def
+}
+
+// ParseArgs split tokens of command to positional arguments and options
+// option is "--name=value" or "--name" (the same as "--name=true")
+// all tokens after "--" are positional arguments. if cmd is OptionsFirstCommand,
+// all tokens after the first positional argument are positional arguments too, including "--"
+func ParseArgs(cmd Command, tokens []string) (*Args, error) {
+	_, optionsFirst := cmd.(OptionsFirstCommand)
+	args := &Args{Options: map[string]string{}}
+	for i, token := range tokens {
+		if optionsFirst && len(args.Values) > 0 {
+			args.Values = append(args.Values, tokens[i:]...)
+			break
+		}
+		if token == "--" {
+			args.Values = append(args.Values, tokens[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(token, "--") {
+			args.Values = append(args.Values, token)
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimPrefix(token, "--"), "=")
+		if name == "" {
+			return nil, fmt.Errorf("wrong option %q", token)
+		}
+		if !found {
+			value = "true"
+		}
+		args.Options[name] = value
+	}
+	return args, nil
+}
+
+// ArgType type of command argument
+type ArgType int
+
+const (
+	String ArgType = iota
+	Int
+	City
+	Bool
+)
+
+func (t ArgType) String() string {
+	switch t {
+	case Int:
+		return "int"
+	case City:
+		return "city"
+	case Bool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// Arg describes one command argument or option
+// Variadic argument takes all the rest values so it must be the last one
+// Values is the list of allowed values. Check is additional validation of value
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Required bool
+	Variadic bool
+	Values   []string
+	Check    func(string) error
+}
+
+// ValidateArgs checks count and types of args and options by command schema
+func ValidateArgs(cmd Command, args *Args) error {
+	if err := validateValues(cmd.Args(), args.Values); err != nil {
+		return err
+	}
+	return validateOptions(cmd.Options(), args.Options)
+}
+
+func validateValues(schema []Arg, values []string) error {
+	i := 0
+	for _, arg := range schema {
+		if i >= len(values) {
+			if arg.Required {
+				return fmt.Errorf("missing required argument %q", arg.Name)
+			}
+			return nil
+		}
+		if arg.Variadic {
+			for ; i < len(values); i++ {
+				if err := ValidateValue(arg, values[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := ValidateValue(arg, values[i]); err != nil {
+			return err
+		}
+		i++
+	}
+	if i < len(values) {
+		return fmt.Errorf("too many arguments: expected %d, got %d", len(schema), len(values))
+	}
+	return nil
+}
+
+func validateOptions(schema []Arg, options map[string]string) error {
+	known := make(map[string]Arg, len(schema))
+	for _, opt := range schema {
+		known[opt.Name] = opt
+	}
+	for name, value := range options {
+		opt, found := known[name]
+		if !found {
+			return fmt.Errorf("unknown option --%s", name)
+		}
+		if err := ValidateValue(opt, value); err != nil {
+			return err
+		}
+	}
+	for _, opt := range schema {
+		if _, found := options[opt.Name]; opt.Required && !found {
+			return fmt.Errorf("missing required option --%s", opt.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks value of argument by its type, allowed values and check function
+func ValidateValue(arg Arg, value string) error {
+	switch arg.Type {
+	case Int:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("argument %q must be %s, got %q", arg.Name, arg.Type, value)
+		}
+	case Bool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("argument %q must be %s, got %q", arg.Name, arg.Type, value)
+		}
+	case City:
+		if _, err := utils.ParseCity(value); err != nil {
+			return fmt.Errorf("argument %q: %w", arg.Name, err)
+		}
+	}
+	if len(arg.Values) > 0 && !contains(arg.Values, value) {
+		return fmt.Errorf("argument %q must be one of %s, got %q", arg.Name, strings.Join(arg.Values, ", "), value)
+	}
+	if arg.Check != nil {
+		if err := arg.Check(value); err != nil {
+			return fmt.Errorf("argument %q: %w", arg.Name, err)
+		}
+	}
+	return nil
+}
+
+// CheckDuration checks that value is duration like 30s or 2h
+func CheckDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("wrong duration %q", value)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage returns short usage string like "weather [--chat=int] <city...>"
+func Usage(cmd Command) string {
+	usage := cmd.Name()
+	for _, opt := range cmd.Options() {
+		usage += fmt.Sprintf(" [--%s=%s]", opt.Name, opt.Type)
+	}
+	for _, arg := range cmd.Args() {
+		name := arg.Name
+		if arg.Variadic {
+			name += "..."
+		}
+		if arg.Required {
+			usage += " <" + name + ">"
+		} else {
+			usage += " [" + name + "]"
+		}
+	}
+	return usage
+}
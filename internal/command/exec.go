@@ -0,0 +1,197 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/history"
+	"weatherbot/internal/telegram/message"
+)
+
+// defaults of exec command. they are overridden by EXEC_TIMEOUT, EXEC_ENV_ALLOW and EXEC_MAX_OUTPUT in config
+const (
+	defaultExecTimeout   = time.Minute
+	defaultExecEnvAllow  = "PATH,HOME,LANG,TZ"
+	defaultExecMaxOutput = 64 * 1024
+)
+
+// notify modes of exec command
+const (
+	NotifyAlways  = "always"
+	NotifyFailure = "failure"
+)
+
+// output formats of exec command
+const (
+	OutputText = "text"
+	OutputFile = "file"
+)
+
+// execCommand runs external program and sends its output (stdout and stderr) to telegram
+// options of command are written before program, the rest of line is passed to program as is
+// exec --timeout=30s --notify=failure --as=file --env=API_TOKEN curl -s https://example.com/health
+type execCommand struct{}
+
+func init() {
+	Register(&execCommand{})
+}
+
+func (c *execCommand) Name() string {
+	return "exec"
+}
+
+// OptionsFirst options of program are not parsed
+func (c *execCommand) OptionsFirst() {}
+
+func (c *execCommand) Args() []Arg {
+	return []Arg{
+		{Name: "program", Required: true, Variadic: true},
+	}
+}
+
+func (c *execCommand) Options() []Arg {
+	return []Arg{
+		{Name: "chat", Type: Int},
+		{Name: "timeout", Check: CheckDuration},
+		{Name: "notify", Values: []string{NotifyAlways, NotifyFailure}},
+		{Name: "as", Values: []string{OutputText, OutputFile}},
+		{Name: "env"},
+		{Name: "max-output", Type: Int},
+	}
+}
+
+func (c *execCommand) Validate(args *Args) error {
+	return ValidateArgs(c, args)
+}
+
+func (c *execCommand) Run(ctx context.Context, app *app.AppContext, args *Args) error {
+	timeout, _ := time.ParseDuration(args.Option("timeout", config.GetConfigValue("EXEC_TIMEOUT")))
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	maxOutput, _ := strconv.Atoi(args.Option("max-output", config.GetConfigValue("EXEC_MAX_OUTPUT")))
+	if maxOutput <= 0 {
+		maxOutput = defaultExecMaxOutput
+	}
+	chatID := app.ChatID
+	if chat := args.Option("chat", ""); chat != "" {
+		chatID, _ = strconv.ParseInt(chat, 10, 64)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &limitedBuffer{limit: maxOutput}
+	cmd := exec.CommandContext(ctx, args.Values[0], args.Values[1:]...)
+	cmd.Env = execEnv(args.Option("env", ""))
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// do not wait forever for children which keep output open
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timeout %s exceeded", timeout)
+	}
+	if err != nil {
+		app.Logger.Errorf("exec %s: %v", args.Values[0], err)
+	}
+
+	if err == nil && args.Option("notify", NotifyAlways) == NotifyFailure {
+		return nil
+	}
+	if sendErr := sendOutput(ctx, app, chatID, args, output, err); sendErr != nil && err == nil {
+		return sendErr
+	}
+	return err
+}
+
+// sendOutput sends output of program as text message or file
+// text which does not fit into telegram message is sent as file
+func sendOutput(ctx context.Context, app *app.AppContext, chatID int64, args *Args, output *limitedBuffer, runErr error) error {
+	// output is sent even if program is cancelled by timeout
+	ctx = context.WithoutCancel(ctx)
+	program := filepath.Base(args.Values[0])
+	header := "$ " + strings.Join(args.Values, " ")
+	status := "exit status 0"
+	if runErr != nil {
+		status = runErr.Error()
+	}
+	if output.truncated {
+		status += fmt.Sprintf(" (output is truncated to %d bytes)", output.limit)
+	}
+
+	var messageID int
+	var err error
+	text := header
+	if body := strings.TrimRight(output.String(), "\n"); body != "" {
+		text += "\n" + body
+	}
+	text += fmt.Sprintf("\n[%s]", status)
+	if args.Option("as", OutputText) == OutputText && len(text) <= message.MaxTextLength {
+		messageID, err = message.SendText(ctx, app, chatID, text)
+	} else {
+		messageID, err = message.SendFile(ctx, app, chatID, program+".txt", output.Bytes(), fmt.Sprintf("%s\n[%s]", header, status))
+	}
+	if err != nil {
+		history.FromContext(ctx).AddOutcome(program, err)
+		return err
+	}
+	history.FromContext(ctx).AddOutcome(program, runErr, messageID)
+	return nil
+}
+
+// execEnv returns environment for program. only variables from EXEC_ENV_ALLOW and --env option are passed
+func execEnv(extra string) []string {
+	allowList := config.GetConfigValue("EXEC_ENV_ALLOW")
+	if allowList == "" {
+		allowList = defaultExecEnvAllow
+	}
+	if extra != "" {
+		allowList += "," + extra
+	}
+
+	var env []string
+	for _, name := range strings.Split(allowList, ",") {
+		name = strings.TrimSpace(name)
+		if value, found := os.LookupEnv(name); found && name != "" {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// limitedBuffer keeps only first limit bytes of output
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if rest := b.limit - b.buf.Len(); len(p) > rest {
+		p = p[:max(rest, 0)]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+	// report full length, so program is not stopped by short write
+	return n, nil
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
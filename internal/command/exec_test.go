@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"weatherbot/internal/app"
+)
+
+// runExec runs exec command in dry run mode and returns sent messages by file name and error of command
+func runExec(t *testing.T, tokens ...string) (map[string]string, error) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	dir := t.TempDir()
+	a := &app.AppContext{Logger: logger, OutputDir: dir}
+
+	cmd := &execCommand{}
+	args, err := ParseArgs(cmd, tokens)
+	if err != nil {
+		t.Fatalf("ParseArgs: %v", err)
+	}
+	if err := cmd.Validate(args); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	runErr := cmd.Run(context.Background(), a, args)
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := map[string]string{}
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// file name has time prefix
+		_, name, _ := strings.Cut(file.Name(), "_")
+		_, name, _ = strings.Cut(name, "_")
+		sent[name] = string(content)
+	}
+	return sent, runErr
+}
+
+func TestExec(t *testing.T) {
+	t.Setenv("EXEC_TEST_VAR", "visible")
+	t.Setenv("EXEC_TEST_SECRET", "hidden")
+
+	tests := []struct {
+		name   string
+		tokens []string
+		err    string
+		file   string
+		want   []string
+	}{
+		{"success", []string{"sh", "-c", "echo hello"}, "", "message.txt", []string{"$ sh -c echo hello\nhello\n[exit status 0]"}},
+		{"failure", []string{"--notify=failure", "sh", "-c", "echo oops >&2; exit 3"}, "exit status 3", "message.txt", []string{"oops", "[exit status 3]"}},
+		{"success is not sent on failure notify", []string{"--notify=failure", "true"}, "", "", nil},
+		{"program options are not parsed", []string{"sh", "-c", "echo $0", "--all"}, "", "message.txt", []string{"\n--all\n"}},
+		{"double dash is passed to program", []string{"echo", "--", "-x"}, "", "message.txt", []string{"\n-- -x\n"}},
+		{"truncated", []string{"--max-output=5", "sh", "-c", "echo 0123456789"}, "", "message.txt", []string{"\n01234\n", "output is truncated to 5 bytes"}},
+		{"as file", []string{"--as=file", "sh", "-c", "echo content"}, "", "sh.txt", []string{"content\n"}},
+		{"only allowed env", []string{"--env=EXEC_TEST_VAR", "sh", "-c", "echo [$EXEC_TEST_VAR] [$EXEC_TEST_SECRET]"}, "", "message.txt", []string{"[visible] []"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent, err := runExec(t, tt.tokens...)
+			if tt.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+			if tt.file == "" {
+				if len(sent) > 0 {
+					t.Fatalf("expected no messages, got %v", sent)
+				}
+				return
+			}
+			text, found := sent[tt.file]
+			if !found || len(sent) != 1 {
+				t.Fatalf("expected message %s, got %v", tt.file, sent)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in message %q", want, text)
+				}
+			}
+		})
+	}
+}
+
+func TestExecTimeout(t *testing.T) {
+	start := time.Now()
+	sent, err := runExec(t, "--timeout=200ms", "sleep", "10")
+	if err == nil || !strings.Contains(err.Error(), "timeout 200ms exceeded") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("program is not killed on timeout, run took %s", elapsed)
+	}
+	if !strings.Contains(sent["message.txt"], "[timeout 200ms exceeded]") {
+		t.Errorf("output must be sent on timeout, got %v", sent)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 8}
+	for _, s := range []string{"abcde", "fghij", "klm"} {
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("write must report full length, got %d %v", n, err)
+		}
+	}
+	if b.String() != "abcdefgh" || !b.truncated {
+		t.Errorf("expected first 8 bytes and truncated flag, got %q %v", b.String(), b.truncated)
+	}
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	mu       sync.RWMutex
+	commands = map[string]Command{}
+)
+
+// Register adds command to registry. usually it is called from init()
+// of the file with command implementation
+func Register(cmd Command) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := cmd.Name()
+	if _, found := commands[name]; found {
+		panic(fmt.Sprintf("command %q already registered", name))
+	}
+	commands[name] = cmd
+}
+
+// Get returns command by name
+func Get(name string) (Command, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	cmd, found := commands[name]
+	return cmd, found
+}
+
+// Names returns sorted list of registered commands
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"weatherbot/internal/app"
+)
+
+// testCommand just for test. it writes given number to log
+type testCommand struct{}
+
+func init() {
+	Register(&testCommand{})
+}
+
+func (c *testCommand) Name() string {
+	return "test"
+}
+
+func (c *testCommand) Args() []Arg {
+	return []Arg{
+		{Name: "value", Type: Int, Required: true},
+	}
+}
+
+func (c *testCommand) Options() []Arg {
+	return nil
+}
+
+func (c *testCommand) Validate(args *Args) error {
+	return ValidateArgs(c, args)
+}
+
+func (c *testCommand) Run(ctx context.Context, app *app.AppContext, args *Args) error {
+	value, err := strconv.Atoi(args.Values[0])
+	if err != nil {
+		return err
+	}
+	app.Logger.Printf("test command: %d", value)
+	return nil
+}
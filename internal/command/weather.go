@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/telegram/message"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/providers"
+	"weatherbot/internal/weather/units"
+)
+
+// ModeAggregate value of WEATHER_MODE to merge data of all providers instead of fallback
+const ModeAggregate = "aggregate"
+
+// weatherCommand get weather for given cities and send it to telegram
+// weather --chat=-100123 --provider=weatherapi --lang=en --template=compact Moscow
+type weatherCommand struct{}
+
+func init() {
+	Register(&weatherCommand{})
+}
+
+func (c *weatherCommand) Name() string {
+	return "weather"
+}
+
+func (c *weatherCommand) Args() []Arg {
+	return []Arg{
+		{Name: "city", Type: City, Required: true, Variadic: true},
+	}
+}
+
+func (c *weatherCommand) Options() []Arg {
+	return []Arg{
+		{Name: "chat", Type: Int},
+		{Name: "provider", Check: checkProviders},
+		{Name: "lang"},
+		{Name: "units", Values: units.Systems},
+		{Name: "pressure", Values: units.PressureUnits},
+		{Name: "template", Check: checkTemplate},
+		{Name: "aggregate", Type: Bool},
+		{Name: "daily", Type: Bool},
+	}
+}
+
+func (c *weatherCommand) Validate(args *Args) error {
+	return ValidateArgs(c, args)
+}
+
+func (c *weatherCommand) Run(ctx context.Context, app *app.AppContext, args *Args) error {
+	opts, err := weatherOptions(app, args)
+	if err != nil {
+		return err
+	}
+	res := providers.GetWeather(ctx, app, args.Values, opts)
+	if len(res) < len(args.Values) {
+		return fmt.Errorf("weather received for %d of %d cities", len(res), len(args.Values))
+	}
+	return nil
+}
+
+// weatherOptions returns task options. not given options are taken from config
+func weatherOptions(app *app.AppContext, args *Args) (*weather.Options, error) {
+	opts := &weather.Options{
+		Providers: providers.Chain(args.Option("provider", "")),
+		ChatID:    app.ChatID,
+		Language:  args.Option("lang", config.GetConfigValue("LANGUAGE")),
+		Template:  args.Option("template", message.DefaultTemplate),
+	}
+	opts.Aggregate, _ = strconv.ParseBool(args.Option("aggregate", "false"))
+	if _, found := args.Options["aggregate"]; !found {
+		opts.Aggregate = config.GetConfigValue("WEATHER_MODE") == ModeAggregate
+	}
+	opts.Daily, _ = strconv.ParseBool(args.Option("daily", "false"))
+	if _, found := args.Options["template"]; !found && opts.Daily {
+		opts.Template = message.DailyTemplate
+	}
+	if chat := args.Option("chat", ""); chat != "" {
+		chatID, err := strconv.ParseInt(chat, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("wrong chat id %q: %w", chat, err)
+		}
+		opts.ChatID = chatID
+	}
+	if err := setUnits(opts, args); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// setUnits sets unit system and pressure unit of task
+// task options take precedence over setting of chat (CHAT_UNITS) and global UNITS/PRESSURE_UNITS
+func setUnits(opts *weather.Options, args *Args) error {
+	chatSystem, chatPressure, err := chatUnits(config.GetConfigValue("CHAT_UNITS"), opts.ChatID)
+	if err != nil {
+		return err
+	}
+	opts.Units = firstNonEmpty(args.Option("units", ""), chatSystem, config.GetConfigValue("UNITS"), units.Metric)
+	opts.Pressure = firstNonEmpty(args.Option("pressure", ""), chatPressure, config.GetConfigValue("PRESSURE_UNITS"), units.DefaultPressure(opts.Units))
+	if !slices.Contains(units.Systems, opts.Units) {
+		return fmt.Errorf("unknown unit system %q (available: %s)", opts.Units, strings.Join(units.Systems, ", "))
+	}
+	if !slices.Contains(units.PressureUnits, opts.Pressure) {
+		return fmt.Errorf("unknown pressure unit %q (available: %s)", opts.Pressure, strings.Join(units.PressureUnits, ", "))
+	}
+	return nil
+}
+
+// chatUnits returns units of chat from comma separated list of settings: -100123=imperial,-100456=metric/hpa
+// pressure unit after slash is optional
+func chatUnits(value string, chatID int64) (system, pressure string, err error) {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		chat, setting, found := strings.Cut(item, "=")
+		if !found {
+			return "", "", fmt.Errorf("wrong CHAT_UNITS item %q", item)
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(chat), 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("wrong chat id in CHAT_UNITS item %q: %w", item, err)
+		}
+		if id == chatID {
+			system, pressure, _ = strings.Cut(strings.TrimSpace(setting), "/")
+			return system, pressure, nil
+		}
+	}
+	return "", "", nil
+}
+
+// firstNonEmpty returns the first not empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// checkProviders checks comma separated list of providers: --provider=openweathermap,weatherapi
+func checkProviders(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if _, found := weather.GetProvider(strings.TrimSpace(name)); !found {
+			return fmt.Errorf("unknown provider %q (available: %s)", name, strings.Join(weather.ProviderNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// checkTemplate checks that template file exists
+func checkTemplate(name string) error {
+	if _, err := os.Stat(message.TemplatePath(name)); err != nil {
+		return fmt.Errorf("template %q not found", name)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package history
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"strings"
+	"sync"
+	"time"
+	"weatherbot/internal/storage"
+)
+
+const (
+	bucketRuns        = "runs"
+	bucketLastSuccess = "last_success"
+)
+
+// DefaultMaxRecords how many records are kept in history
+const DefaultMaxRecords = 10000
+
+// run statuses
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Outcome result of run for one target (city)
+type Outcome struct {
+	Target     string `json:"target"`
+	Error      string `json:"error,omitempty"`
+	MessageIDs []int  `json:"message_ids,omitempty"`
+}
+
+// Record one run of task
+type Record struct {
+	ID       uint64    `json:"id"`
+	TaskID   string    `json:"task_id"`
+	Command  string    `json:"command"`
+	Name     string    `json:"name"`
+	Args     []string  `json:"args"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	Outcomes []Outcome `json:"outcomes,omitempty"`
+
+	mu sync.Mutex
+}
+
+// AddOutcome adds result for given target. it is safe to call it for nil record
+func (r *Record) AddOutcome(target string, err error, messageIDs ...int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	outcome := Outcome{Target: target, MessageIDs: messageIDs}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+	r.Outcomes = append(r.Outcomes, outcome)
+}
+
+// HasOutcome checks if record has result for given target. it is safe to call it for nil record
+func (r *Record) HasOutcome(target string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, outcome := range r.Outcomes {
+		if outcome.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Finish sets end time and status of run
+func (r *Record) Finish(err error) {
+	r.End = time.Now()
+	r.Status = StatusSuccess
+	if err != nil {
+		r.Status = StatusFailed
+		r.Error = err.Error()
+	}
+}
+
+type recordKey struct{}
+
+// NewContext returns context with record. commands use it to report outcomes
+func NewContext(ctx context.Context, r *Record) context.Context {
+	return context.WithValue(ctx, recordKey{}, r)
+}
+
+// FromContext returns record from context or nil
+func FromContext(ctx context.Context) *Record {
+	r, _ := ctx.Value(recordKey{}).(*Record)
+	return r
+}
+
+// Filter conditions for list of records
+type Filter struct {
+	Command string
+	Status  string
+	Target  string
+	Since   time.Time
+	Limit   int
+}
+
+func (f *Filter) match(r *Record) bool {
+	if f.Command != "" && r.Name != f.Command {
+		return false
+	}
+	if f.Status != "" && r.Status != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && r.Start.Before(f.Since) {
+		return false
+	}
+	if f.Target != "" {
+		found := false
+		for _, outcome := range r.Outcomes {
+			if strings.EqualFold(outcome.Target, f.Target) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// History persistent history of task runs
+type History struct {
+	store      *storage.Store
+	maxRecords int
+}
+
+// New returns history which uses given store
+func New(store *storage.Store, maxRecords int) *History {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	return &History{store: store, maxRecords: maxRecords}
+}
+
+// Add saves record. the oldest records are removed if there are more than maxRecords
+func (h *History) Add(r *Record) error {
+	return h.store.Update(bucketRuns, func(b *bolt.Bucket) error {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.ID = id
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(id), data); err != nil {
+			return err
+		}
+
+		c := b.Cursor()
+		count := 0
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			count++
+		}
+		var old [][]byte
+		for k, _ := c.First(); k != nil && len(old) < count-h.maxRecords; k, _ = c.Next() {
+			old = append(old, append([]byte(nil), k...))
+		}
+		for _, k := range old {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List returns records matched by filter, the newest first
+func (h *History) List(f Filter) ([]*Record, error) {
+	var res []*Record
+	err := h.store.View(bucketRuns, func(b *bolt.Bucket) error {
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			r := &Record{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return fmt.Errorf("wrong record %d: %w", btoi(k), err)
+			}
+			if !f.match(r) {
+				continue
+			}
+			res = append(res, r)
+			if f.Limit > 0 && len(res) >= f.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+// SetLastSuccess saves time of the last successful run of task
+func (h *History) SetLastSuccess(taskID string, t time.Time) error {
+	return h.store.Update(bucketLastSuccess, func(b *bolt.Bucket) error {
+		data, err := t.MarshalText()
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(taskID), data)
+	})
+}
+
+// LastSuccess returns time of the last successful run of task. zero time if task never succeeded
+func (h *History) LastSuccess(taskID string) (t time.Time, err error) {
+	err = h.store.View(bucketLastSuccess, func(b *bolt.Bucket) error {
+		data := b.Get([]byte(taskID))
+		if data == nil {
+			return nil
+		}
+		return t.UnmarshalText(data)
+	})
+	return
+}
+
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func btoi(b []byte) uint64 {
+	return binary.BigEndian.Uint64(b)
+}
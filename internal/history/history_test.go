@@ -0,0 +1,46 @@
+package history
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+	"weatherbot/internal/storage"
+)
+
+func TestHistory(t *testing.T) {
+	h := New(storage.New(filepath.Join(t.TempDir(), "test.db")), 3)
+
+	for i := 0; i < 5; i++ {
+		r := &Record{Command: "weather Moscow Yekaterinburg", Name: "weather", Start: time.Now()}
+		r.AddOutcome("Moscow", nil, 100+i)
+		var err error
+		if i%2 == 0 {
+			err = errors.New("weather data not received")
+			r.AddOutcome("Yekaterinburg", err)
+		}
+		r.Finish(err)
+		if err := h.Add(r); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+	}
+
+	records, err := h.List(Filter{})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(records) != 3 || records[0].ID != 5 || records[2].ID != 3 {
+		t.Fatalf("expected records 5..3, got %d records", len(records))
+	}
+	if ids := records[0].Outcomes[0].MessageIDs; len(ids) != 1 || ids[0] != 104 {
+		t.Errorf("expected message id 104, got %v", ids)
+	}
+
+	failed, err := h.List(Filter{Status: StatusFailed, Target: "yekaterinburg"})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(failed) != 2 {
+		t.Errorf("expected 2 failed runs, got %d", len(failed))
+	}
+}
@@ -0,0 +1,108 @@
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// Holder info about process which holds the lock. it is written to lock file
+type Holder struct {
+	Host  string
+	PID   int
+	Since time.Time
+}
+
+func (h Holder) String() string {
+	return fmt.Sprintf("pid %d on %s since %s", h.PID, h.Host, h.Since.Format(time.DateTime))
+}
+
+// Lock exclusive lock on file (flock). lock is held until Release or process exit
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// New returns lock on given file. file is created if it does not exist
+func New(path string) *Lock {
+	return &Lock{path: path}
+}
+
+// Path returns path to lock file
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// TryLock tries to take lock without waiting. returns false if lock is held by another process
+func (l *Lock) TryLock() (bool, error) {
+	if l.file != nil {
+		return true, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return false, err
+	}
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return false, err
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return false, nil
+		}
+		return false, err
+	}
+	l.file = file
+
+	if err := l.writeHolder(); err != nil {
+		l.Release()
+		return false, err
+	}
+	return true, nil
+}
+
+// writeHolder writes info about current process to lock file
+func (l *Lock) writeHolder() error {
+	host, _ := os.Hostname()
+	data, err := json.Marshal(Holder{Host: host, PID: os.Getpid(), Since: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := l.file.WriteAt(data, 0); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// Release releases lock. lock file is kept, so other processes wait on the same inode
+func (l *Lock) Release() error {
+	if l.file == nil {
+		return nil
+	}
+	err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// ReadHolder reads info about lock holder from lock file
+func ReadHolder(path string) (*Holder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	holder := &Holder{}
+	if err := json.Unmarshal(data, holder); err != nil {
+		return nil, fmt.Errorf("wrong lock file %s: %w", path, err)
+	}
+	return holder, nil
+}
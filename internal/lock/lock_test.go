@@ -0,0 +1,38 @@
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weatherbot.lock")
+
+	leader := New(path)
+	ok, err := leader.TryLock()
+	if err != nil || !ok {
+		t.Fatalf("expected lock to be taken, got %v, %v", ok, err)
+	}
+
+	standby := New(path)
+	if ok, err := standby.TryLock(); err != nil || ok {
+		t.Fatalf("expected lock to be busy, got %v, %v", ok, err)
+	}
+
+	holder, err := ReadHolder(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if holder.PID != os.Getpid() {
+		t.Errorf("expected holder pid %d, got %d", os.Getpid(), holder.PID)
+	}
+
+	if err := leader.Release(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, err := standby.TryLock(); err != nil || !ok {
+		t.Fatalf("expected lock to be taken after release, got %v, %v", ok, err)
+	}
+	standby.Release()
+}
@@ -13,8 +13,8 @@ const logFile = "app.log"
 
 var log = logrus.New()
 
-// InitLogger initialize logger
-func InitLogger() {
+// InitLogger initialize logger. log is written to file and to stdout if toStdout is set
+func InitLogger(toStdout bool) {
 	if _, err := os.Stat(logDir); os.IsNotExist(err) {
 		err := os.Mkdir(logDir, 0755)
 		if err != nil {
@@ -28,8 +28,11 @@ func InitLogger() {
 		log.Fatalf("Failed to open log file: %v", err)
 	}
 
-	mw := io.MultiWriter(os.Stdout, file)
-	log.SetOutput(mw)
+	if toStdout {
+		log.SetOutput(io.MultiWriter(os.Stdout, file))
+	} else {
+		log.SetOutput(file)
+	}
 
 	log.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp:   true,
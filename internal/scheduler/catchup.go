@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"github.com/robfig/cron/v3"
+	"sync"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+)
+
+// catchUpJobs running catch up jobs. they are not started by cron, so cron does not wait for them
+var catchUpJobs sync.WaitGroup
+
+// catchUpWindow returns window for missed runs of task or default one from config
+func catchUpWindow(task Task) time.Duration {
+	if task.CatchUp > 0 {
+		return task.CatchUp
+	}
+	window, err := time.ParseDuration(config.GetConfigValue("CATCHUP_WINDOW"))
+	if err != nil {
+		return 0
+	}
+	return window
+}
+
+// catchUp runs tasks whose scheduled time was missed (e.g. host was down) within catch up window
+// task is run if its last success is earlier than missed time or it has never succeeded
+// (e.g. its first run fell in downtime)
+func catchUp(app *app.AppContext, entries map[string]Entry, cr *cron.Cron, now time.Time) {
+	if app.History == nil {
+		return
+	}
+
+	for _, entry := range entries {
+		task := entry.Task
+		window := catchUpWindow(task)
+		if window <= 0 {
+			continue
+		}
+		schedule, err := cronParser.Parse(task.Schedule)
+		if err != nil {
+			continue
+		}
+		missed := lastScheduled(schedule, now.Add(-window), now)
+		if missed.IsZero() {
+			continue
+		}
+
+		lastSuccess, err := app.History.LastSuccess(task.ID())
+		if err != nil {
+			app.Logger.Errorf("Failed to read last success of task %s: %v", task.Command, err)
+			continue
+		}
+		if !lastSuccess.IsZero() && !lastSuccess.Before(missed) {
+			continue
+		}
+
+		if lastSuccess.IsZero() {
+			app.Logger.Printf("Task %s missed run at %s (never succeeded). Running it now",
+				task.Command, missed.Format(time.DateTime))
+		} else {
+			app.Logger.Printf("Task %s missed run at %s (last success %s). Running it now",
+				task.Command, missed.Format(time.DateTime), lastSuccess.Format(time.DateTime))
+		}
+		job := cr.Entry(entry.ID).Job
+		catchUpJobs.Add(1)
+		go func() {
+			defer catchUpJobs.Done()
+			job.Run()
+		}()
+	}
+}
+
+// lastScheduled returns the last scheduled time in interval [from, to) or zero time
+// tasks with @every schedule have no fixed time, so they are never missed
+func lastScheduled(schedule cron.Schedule, from, to time.Time) (last time.Time) {
+	if _, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return
+	}
+	for t := schedule.Next(from.Add(-time.Second)); !t.IsZero() && t.Before(to); t = schedule.Next(t) {
+		last = t
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"io"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+	"weatherbot/internal/app"
+	"weatherbot/internal/history"
+	"weatherbot/internal/storage"
+)
+
+func TestLastScheduled(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, loc)
+	tests := []struct {
+		schedule string
+		window   time.Duration
+		want     time.Time
+	}{
+		{"CRON_TZ=UTC 30 8 * * *", 2 * time.Hour, time.Date(2026, 10, 18, 8, 30, 0, 0, loc)},
+		{"CRON_TZ=UTC 30 8 * * *", 10 * time.Minute, time.Time{}},
+		{"CRON_TZ=UTC 0 */2 * * *", 3 * time.Hour, time.Date(2026, 10, 18, 8, 0, 0, 0, loc)},
+		{"CRON_TZ=UTC 0 9 * * *", time.Hour, time.Time{}},
+		{"@every 1h", 24 * time.Hour, time.Time{}},
+	}
+	for _, tt := range tests {
+		schedule, err := cronParser.Parse(tt.schedule)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.schedule, err)
+		}
+		got := lastScheduled(schedule, now.Add(-tt.window), now)
+		if !got.Equal(tt.want) {
+			t.Errorf("lastScheduled(%q, %s) = %s; want %s", tt.schedule, tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	missed := time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		lastSuccess time.Time
+		want        int32
+	}{
+		{"never succeeded", time.Time{}, 1},
+		{"succeeded before missed run", missed.Add(-24 * time.Hour), 1},
+		{"succeeded after missed run", missed.Add(time.Minute), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			a := &app.AppContext{
+				Logger:  logger,
+				History: history.New(storage.New(filepath.Join(t.TempDir(), "test.db")), 10),
+			}
+			task := Task{Schedule: "CRON_TZ=UTC 30 8 * * *", Command: "weather Moscow", CatchUp: 2 * time.Hour}
+			if !tt.lastSuccess.IsZero() {
+				if err := a.History.SetLastSuccess(task.ID(), tt.lastSuccess); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var runs atomic.Int32
+			cr := cron.New(cron.WithParser(cronParser))
+			id, err := cr.AddFunc(task.Schedule, func() { runs.Add(1) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			catchUp(a, map[string]Entry{task.ID(): {ID: id, Task: task}}, cr, now)
+			catchUpJobs.Wait()
+
+			if got := runs.Load(); got != tt.want {
+				t.Errorf("expected %d runs, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Check parse crontab, writes all found problems and next run times of valid tasks
+// returns error if crontab can't be read or has wrong lines
+func Check(w io.Writer, crontab string, next int) error {
+	tasks, err := ParseConfig(crontab)
+	var parseErrors ParseErrors
+	if err != nil && !errors.As(err, &parseErrors) {
+		return err
+	}
+
+	for _, lineErr := range parseErrors {
+		fmt.Fprintf(w, "ERROR %v\n", lineErr)
+	}
+
+	now := time.Now()
+	for _, task := range tasks {
+		fmt.Fprintf(w, "OK    %s:%d: %s %s\n", crontab, task.Line, task.Schedule, task.Command)
+		schedule, err := cronParser.Parse(task.Schedule)
+		if err != nil {
+			continue
+		}
+		loc := scheduleLocation(task.Schedule)
+		t := now
+		for i := 0; i < next; i++ {
+			t = schedule.Next(t)
+			if t.IsZero() {
+				break
+			}
+			fmt.Fprintf(w, "      %s\n", t.In(loc).Format("2006-01-02 15:04:05 MST Mon"))
+		}
+	}
+
+	fmt.Fprintf(w, "%d task(s), %d error(s)\n", len(tasks), len(parseErrors))
+	if len(parseErrors) > 0 {
+		return parseErrors
+	}
+	return nil
+}
+
+// scheduleLocation returns timezone from CRON_TZ= prefix of schedule or local timezone
+func scheduleLocation(schedule string) *time.Location {
+	if strings.HasPrefix(schedule, "CRON_TZ=") || strings.HasPrefix(schedule, "TZ=") {
+		_, rest, _ := strings.Cut(schedule, "=")
+		tz, _, _ := strings.Cut(rest, " ")
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
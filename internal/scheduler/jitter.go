@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+)
+
+// stopping is cancelled on shutdown. delayed runs which are not started yet are dropped
+var stopping, stopDelayed = context.WithCancel(context.Background())
+
+// taskJitter returns jitter of task if it is set, otherwise default one from config
+func taskJitter(task Task) time.Duration {
+	if task.JitterSet {
+		return task.Jitter
+	}
+	jitter, err := time.ParseDuration(config.GetConfigValue("TASK_JITTER"))
+	if err != nil || jitter < 0 {
+		return 0
+	}
+	return jitter
+}
+
+// startDelay returns random delay of task run within [0, jitter)
+func startDelay(task Task) time.Duration {
+	jitter := taskJitter(task)
+	if jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter).Round(time.Second)
+}
+
+// waitDelay waits for delay of run. returns false if scheduler is stopping or app context is cancelled
+func waitDelay(app *app.AppContext, task Task, run *Run) bool {
+	app.Logger.Printf("Task %s: start is delayed by %s", task.Command, run.Delay)
+	timer := time.NewTimer(run.Delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		runs.Started(run)
+		return true
+	case <-stopping.Done():
+	case <-app.Context.Done():
+	}
+	app.Logger.Printf("Task %s: delayed run is dropped on shutdown", task.Command)
+	runs.Discard(run)
+	return false
+}
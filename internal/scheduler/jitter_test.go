@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"github.com/spf13/viper"
+	"testing"
+	"time"
+)
+
+func TestTaskJitter(t *testing.T) {
+	viper.Set("TASK_JITTER", "2m")
+	defer viper.Set("TASK_JITTER", "")
+
+	tests := []struct {
+		line string
+		want time.Duration
+	}{
+		{"0 9 * * * weather Moscow", 2 * time.Minute},
+		{"0 9 * * * weather --jitter=30s Moscow", 30 * time.Second},
+		{"0 9 * * * weather --jitter=0 Moscow", 0},
+	}
+	for _, tt := range tests {
+		task, err := parseLine(tt.line)
+		if err != nil {
+			t.Fatalf("parseLine(%q) error: %v", tt.line, err)
+		}
+		if got := taskJitter(task); got != tt.want {
+			t.Errorf("taskJitter(%q) = %s; want %s", tt.line, got, tt.want)
+		}
+		if tt.want == 0 && startDelay(task) != 0 {
+			t.Errorf("startDelay(%q) must be zero", tt.line)
+		}
+	}
+}
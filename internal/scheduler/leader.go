@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/lock"
+)
+
+// DefaultLockFile default path to lock file
+const DefaultLockFile = "data/weatherbot.lock"
+
+// lockRetryInterval how often standby instance tries to take the lock
+const lockRetryInterval = 5 * time.Second
+
+// LockFile returns path to lock file from config
+func LockFile() string {
+	if path := config.GetConfigValue("LOCK_FILE"); path != "" {
+		return path
+	}
+	return DefaultLockFile
+}
+
+// acquireLock takes exclusive lock, so only one instance runs tasks of crontab
+// if lock is held by another instance, this one waits in standby mode and takes over when leader exits
+// returns false if instance is stopped while waiting. SIGHUP is ignored as there are no tasks yet
+func acquireLock(app *app.AppContext, signals <-chan os.Signal) (*lock.Lock, bool) {
+	lk := lock.New(LockFile())
+
+	var holder *lock.Holder
+	for {
+		ok, err := lk.TryLock()
+		if err != nil {
+			app.Logger.Fatalf("Failed to take lock file %s: %v", lk.Path(), err)
+		}
+		if ok {
+			app.Logger.Printf("Lock file %s is taken. This instance is the leader", lk.Path())
+			return lk, true
+		}
+
+		current, err := lock.ReadHolder(lk.Path())
+		if err != nil {
+			app.Logger.Printf("Failed to read lock holder: %v", err)
+		} else if holder == nil || *current != *holder {
+			app.Logger.Printf("Lock file %s is held by %s. Waiting in standby mode", lk.Path(), current)
+			holder = current
+		}
+
+		select {
+		case <-time.After(lockRetryInterval):
+		case sig := <-signals:
+			switch sig {
+			case syscall.SIGUSR1:
+				writeStandbyStatus(app, holder)
+				continue
+			case syscall.SIGHUP:
+				continue
+			}
+			app.Logger.Printf("Received signal %s in standby mode. Exiting", sig)
+			return nil, false
+		case <-app.Context.Done():
+			return nil, false
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"weatherbot/config"
+)
+
+// overlap policies. what to do when task is fired while previous run is not finished
+const (
+	OverlapSkip  = "skip"
+	OverlapQueue = "queue"
+	OverlapAllow = "allow"
+)
+
+// OverlapPolicies list of supported policies
+var OverlapPolicies = []string{OverlapSkip, OverlapQueue, OverlapAllow}
+
+// overlapPolicy returns policy of task or default one from config
+func overlapPolicy(task Task) string {
+	if task.Overlap != "" {
+		return task.Overlap
+	}
+	if policy := config.GetConfigValue("TASK_OVERLAP"); policy != "" {
+		return policy
+	}
+	return OverlapSkip
+}
+
+// overlapWrapper returns cron job wrapper for given policy
+func overlapWrapper(policy string, logger cron.Logger) cron.JobWrapper {
+	switch policy {
+	case OverlapQueue:
+		return cron.DelayIfStillRunning(logger)
+	case OverlapAllow:
+		return func(j cron.Job) cron.Job {
+			return j
+		}
+	default:
+		return cron.SkipIfStillRunning(logger)
+	}
+}
+
+// cronLogger adapter of logrus logger for cron package
+type cronLogger struct {
+	log    *logrus.Logger
+	prefix string
+}
+
+func (l cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log.WithFields(logFields(keysAndValues)).Infof("Task %s: %s", l.prefix, msg)
+}
+
+func (l cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.log.WithFields(logFields(keysAndValues)).WithError(err).Errorf("Task %s: %s", l.prefix, msg)
+}
+
+func logFields(keysAndValues []interface{}) logrus.Fields {
+	fields := logrus.Fields{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	return fields
+}
@@ -1,11 +1,64 @@
 package scheduler
 
 import (
+	"fmt"
+	"github.com/robfig/cron/v3"
 	"os"
+	"regexp"
 	"strings"
+	"time"
+	"unicode"
+	"weatherbot/internal/command"
 )
 
+// cronParser parser of schedules. it supports standard five fields, optional seconds field
+// (six fields), descriptors (@daily, @every 2h) and CRON_TZ=Asia/Yekaterinburg prefix
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// cronFieldRe one field of schedule: *, ?, numbers, names (MON, JAN), ranges, steps and lists
+var cronFieldRe = regexp.MustCompile(`^(\*|\?|\d+|[A-Za-z]{3})(-(\d+|[A-Za-z]{3}))?(/\d+)?(,(\*|\?|\d+|[A-Za-z]{3})(-(\d+|[A-Za-z]{3}))?(/\d+)?)*$`)
+
+// taskOptions options of any task which are handled by scheduler and not passed to command
+var taskOptions = []command.Arg{
+	{Name: "overlap", Values: OverlapPolicies},
+	{Name: "catchup", Check: command.CheckDuration},
+	{Name: "jitter", Check: command.CheckDuration},
+}
+
+// schedule fields count
+const (
+	standardFields = 5
+	secondsFields  = 6
+)
+
+// LineError error in given line of crontab file
+type LineError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors list of errors found while parsing crontab
+type ParseErrors []*LineError
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
 // ParseConfig - parse crontab file
+// wrong lines (bad schedule, unknown command, wrong arguments) are skipped and returned as ParseErrors
 func ParseConfig(crontab string) ([]Task, error) {
 	content, err := os.ReadFile(crontab)
 	if err != nil {
@@ -14,19 +67,196 @@ func ParseConfig(crontab string) ([]Task, error) {
 
 	lines := strings.Split(string(content), "\n")
 	var tasks []Task
+	var errs ParseErrors
 
-	for _, line := range lines {
+	for i, line := range lines {
 		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
 
-		parts := strings.Fields(line)
-		if len(parts) >= 6 {
-			schedule := strings.Join(parts[0:5], " ")
-			command := strings.Join(parts[5:], " ")
-			tasks = append(tasks, Task{Schedule: schedule, Command: command})
+		task, err := parseLine(line)
+		if err != nil {
+			errs = append(errs, &LineError{File: crontab, Line: i + 1, Err: err})
+			continue
 		}
+		task.Line = i + 1
+		tasks = append(tasks, task)
 	}
 
+	if len(errs) > 0 {
+		return tasks, errs
+	}
 	return tasks, nil
 }
+
+// parseLine split line to schedule and command and check both of them
+func parseLine(line string) (Task, error) {
+	parts := strings.Fields(line)
+	n, err := scheduleFields(parts)
+	if err != nil {
+		return Task{}, err
+	}
+
+	schedule := strings.Join(parts[0:n], " ")
+	if _, err := cronParser.Parse(schedule); err != nil {
+		return Task{}, fmt.Errorf("bad schedule %q: %w", schedule, err)
+	}
+
+	// command is kept as is, so spaces inside quoted arguments are not changed
+	return newTask(schedule, skipFields(line, n))
+}
+
+// skipFields returns rest of line after n fields separated by spaces
+func skipFields(line string, n int) string {
+	rest := strings.TrimSpace(line)
+	for ; n > 0; n-- {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			return ""
+		}
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	return rest
+}
+
+// scheduleFields returns count of fields which belongs to schedule. the rest fields are command
+// 30 8 * * * weather Moscow -> 5
+// 0 30 8 * * * weather Moscow -> 6 (with seconds)
+// CRON_TZ=Asia/Yekaterinburg 30 8 * * * weather Moscow -> 6
+// @daily weather Moscow -> 1; @every 2h weather Moscow -> 2
+func scheduleFields(parts []string) (int, error) {
+	i := 0
+	if len(parts) > 0 && (strings.HasPrefix(parts[0], "CRON_TZ=") || strings.HasPrefix(parts[0], "TZ=")) {
+		_, tz, _ := strings.Cut(parts[0], "=")
+		if _, err := time.LoadLocation(tz); err != nil {
+			return 0, fmt.Errorf("bad timezone %q: %w", tz, err)
+		}
+		i++
+	}
+
+	n := standardFields
+	switch {
+	case i < len(parts) && parts[i] == "@every":
+		n = 2
+	case i < len(parts) && strings.HasPrefix(parts[i], "@"):
+		n = 1
+	case len(parts) > i+secondsFields && isCronField(parts[i+standardFields]):
+		n = secondsFields
+	}
+
+	if len(parts) <= i+n {
+		return 0, fmt.Errorf("expected schedule and command, got %d fields", len(parts))
+	}
+	return i + n, nil
+}
+
+// isCronField checks if field looks like schedule field and it is not a command name
+func isCronField(field string) bool {
+	if _, found := command.Get(field); found {
+		return false
+	}
+	return cronFieldRe.MatchString(field)
+}
+
+// newTask parse command string and check it by command schema
+func newTask(schedule, cmd string) (Task, error) {
+	return commandTask(schedule, cmd, splitArgs(strings.Trim(cmd, `"`)))
+}
+
+// commandTask creates task from command line already split to parts
+func commandTask(schedule, cmd string, parts []string) (Task, error) {
+	task := Task{Schedule: schedule, Command: cmd}
+
+	if len(parts) == 0 {
+		return task, fmt.Errorf("empty command")
+	}
+	c, found := command.Get(parts[0])
+	if !found {
+		return task, fmt.Errorf("unknown command %q (available: %s)", parts[0], strings.Join(command.Names(), ", "))
+	}
+	task.Name = parts[0]
+	args, err := command.ParseArgs(c, parts[1:])
+	if err != nil {
+		return task, fmt.Errorf("%s: %w", task.Name, err)
+	}
+	if err := task.takeOptions(args); err != nil {
+		return task, err
+	}
+	task.Args = args
+	if err := c.Validate(task.Args); err != nil {
+		return task, fmt.Errorf("%s: %w (usage: %s)", task.Name, err, command.Usage(c))
+	}
+
+	return task, nil
+}
+
+// takeOptions moves scheduler options (see taskOptions) from command args to task
+func (t *Task) takeOptions(args *command.Args) error {
+	for _, opt := range taskOptions {
+		value, found := args.Options[opt.Name]
+		if !found {
+			continue
+		}
+		delete(args.Options, opt.Name)
+		if err := command.ValidateValue(opt, value); err != nil {
+			return err
+		}
+		switch opt.Name {
+		case "overlap":
+			t.Overlap = value
+		case "catchup":
+			t.CatchUp, _ = time.ParseDuration(value)
+		case "jitter":
+			t.Jitter, _ = time.ParseDuration(value)
+			t.JitterSet = true
+		}
+	}
+	return nil
+}
+
+// splitArgs split command line by spaces. spaces inside brackets and quotes are kept
+// so city with coordinates Moscow[55.7558 37.6176] is one argument
+func splitArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	depth := 0
+	hasArg := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			hasArg = true
+		case r == '[':
+			depth++
+			current.WriteRune(r)
+			hasArg = true
+		case r == ']':
+			if depth > 0 {
+				depth--
+			}
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && depth == 0:
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(r)
+			hasArg = true
+		}
+	}
+	if hasArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
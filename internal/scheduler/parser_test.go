@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeCrontab(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "crontab")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"weather Moscow", []string{"weather", "Moscow"}},
+		{"weather  Moscow[55.7558 37.6176] Yekaterinburg", []string{"weather", "Moscow[55.7558 37.6176]", "Yekaterinburg"}},
+		{`weather "Nizhny Novgorod"`, []string{"weather", "Nizhny Novgorod"}},
+	}
+	for _, tt := range tests {
+		got := splitArgs(tt.line)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q; want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	path := writeCrontab(t, `# min hour day month weekday command
+30 8 * * * weather Moscow[55.7558 37.6176] Yekaterinburg
+* * * * * test 42
+* * * * * test abc
+* * * * * unknown Moscow
+* * * * * weather
+61 * * * * weather Moscow
+* * * weather Moscow
+* * * * * weather --provider=weatherapi --chat=-100123 --lang=en Moscow
+* * * * * weather --color=red Moscow
+* * * * * weather --provider=unknown Moscow
+`)
+
+	tasks, err := ParseConfig(path)
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	if opts := tasks[2].Args.Options; opts["provider"] != "weatherapi" || opts["chat"] != "-100123" || opts["lang"] != "en" {
+		t.Errorf("unexpected options %v", opts)
+	}
+	if tasks[0].Line != 2 || tasks[1].Line != 3 {
+		t.Errorf("expected tasks in lines 2 and 3, got %d and %d", tasks[0].Line, tasks[1].Line)
+	}
+	if want := []string{"Moscow[55.7558 37.6176]", "Yekaterinburg"}; !reflect.DeepEqual(tasks[0].Args.Values, want) {
+		t.Errorf("expected args %q, got %q", want, tasks[0].Args.Values)
+	}
+
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) {
+		t.Fatalf("expected ParseErrors, got %v", err)
+	}
+	lines := []int{}
+	for _, e := range parseErrors {
+		lines = append(lines, e.Line)
+	}
+	if want := []int{4, 5, 6, 7, 8, 10, 11}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("expected errors in lines %v, got %v", want, lines)
+	}
+}
+
+func TestParseLineSchedule(t *testing.T) {
+	tests := []struct {
+		line     string
+		schedule string
+		err      bool
+	}{
+		{"30 8 * * * weather Moscow", "30 8 * * *", false},
+		{"0 30 8 * * * weather Moscow", "0 30 8 * * *", false},
+		{"*/15 * * * MON-FRI test 1", "*/15 * * * MON-FRI", false},
+		{"CRON_TZ=Asia/Yekaterinburg 30 8 * * * weather Yekaterinburg", "CRON_TZ=Asia/Yekaterinburg 30 8 * * *", false},
+		{"CRON_TZ=Asia/Yekaterinburg 0 30 8 * * * weather Yekaterinburg", "CRON_TZ=Asia/Yekaterinburg 0 30 8 * * *", false},
+		{"@daily weather Moscow", "@daily", false},
+		{"@every 2h weather Moscow", "@every 2h", false},
+		{"CRON_TZ=Europe/Moscow @daily weather Moscow", "CRON_TZ=Europe/Moscow @daily", false},
+		{"CRON_TZ=Nowhere/City 30 8 * * * weather Moscow", "", true},
+		{"@every weather Moscow", "", true},
+		{"@yearly", "", true},
+	}
+	for _, tt := range tests {
+		task, err := parseLine(tt.line)
+		if (err != nil) != tt.err {
+			t.Errorf("parseLine(%q) error = %v; want error %v", tt.line, err, tt.err)
+			continue
+		}
+		if task.Schedule != tt.schedule {
+			t.Errorf("parseLine(%q) schedule = %q; want %q", tt.line, task.Schedule, tt.schedule)
+		}
+	}
+}
+
+func TestParseLineTaskOptions(t *testing.T) {
+	task, err := parseLine("* * * * * test --overlap=queue 5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Overlap != OverlapQueue {
+		t.Errorf("expected overlap %q, got %q", OverlapQueue, task.Overlap)
+	}
+	if _, found := task.Args.Options["overlap"]; found {
+		t.Error("scheduler option must not be passed to command")
+	}
+
+	if _, err := parseLine("* * * * * test --overlap=never 5"); err == nil {
+		t.Error("expected error for unknown overlap policy")
+	}
+
+	task, err = parseLine("* * * * * test --jitter=5m 5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Jitter != 5*time.Minute {
+		t.Errorf("expected jitter 5m, got %s", task.Jitter)
+	}
+	for i := 0; i < 100; i++ {
+		if delay := startDelay(task); delay < 0 || delay > task.Jitter {
+			t.Fatalf("delay %s is out of jitter window %s", delay, task.Jitter)
+		}
+	}
+}
+
+func TestParseLineExecArgs(t *testing.T) {
+	task, err := parseLine(`*/5 * * * *  exec --timeout=5s --overlap=queue sh -c "echo  a   b" --all`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Command != `exec --timeout=5s --overlap=queue sh -c "echo  a   b" --all` {
+		t.Errorf("command must be kept as is, got %q", task.Command)
+	}
+	want := []string{"sh", "-c", "echo  a   b", "--all"}
+	if !reflect.DeepEqual(task.Args.Values, want) {
+		t.Errorf("expected program args %q, got %q", want, task.Args.Values)
+	}
+	if task.Args.Option("timeout", "") != "5s" || task.Overlap != OverlapQueue {
+		t.Errorf("options before program must be parsed, got %v %q", task.Args.Options, task.Overlap)
+	}
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// RunStatus status of task run
+type RunStatus string
+
+const (
+	StatusDelayed RunStatus = "delayed"
+	StatusRunning RunStatus = "running"
+	StatusSuccess RunStatus = "success"
+	StatusFailed  RunStatus = "failed"
+)
+
+// Run info about one run of task
+type Run struct {
+	ID      uint64
+	TaskID  string
+	Command string
+	Args    []string
+	Start   time.Time
+	End     time.Time
+	Status  RunStatus
+	Error   string
+	// Delay random delay of start (jitter). Start of delayed run is planned start time
+	Delay time.Duration
+}
+
+// Registry keeps running tasks and the last run of each task
+type Registry struct {
+	mu      sync.RWMutex
+	nextID  uint64
+	running map[uint64]*Run
+	last    map[string]*Run
+}
+
+// runs registry of scheduler tasks
+var runs = NewRegistry()
+
+// NewRegistry returns empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		running: map[uint64]*Run{},
+		last:    map[string]*Run{},
+	}
+}
+
+// Running returns list of currently running tasks of scheduler
+func Running() []Run {
+	return runs.Running()
+}
+
+// LastRuns returns the last finished run of each task of scheduler
+func LastRuns() []Run {
+	return runs.LastRuns()
+}
+
+// Begin registers start of task run. run with delay gets delayed status until Started is called
+func (r *Registry) Begin(task Task, delay time.Duration) *Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	run := &Run{
+		ID:      r.nextID,
+		TaskID:  task.ID(),
+		Command: task.Command,
+		Start:   time.Now().Add(delay),
+		Status:  StatusRunning,
+		Delay:   delay,
+	}
+	if delay > 0 {
+		run.Status = StatusDelayed
+	}
+	if task.Args != nil {
+		run.Args = task.Args.Values
+	}
+	r.running[run.ID] = run
+	return run
+}
+
+// Started marks delayed run as running
+func (r *Registry) Started(run *Run) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.Start = time.Now()
+	run.Status = StatusRunning
+}
+
+// Discard removes run which was not started
+func (r *Registry) Discard(run *Run) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.running, run.ID)
+}
+
+// Finish moves run from running list to the last runs
+func (r *Registry) Finish(run *Run, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.End = time.Now()
+	run.Status = StatusSuccess
+	if err != nil {
+		run.Status = StatusFailed
+		run.Error = err.Error()
+	}
+	delete(r.running, run.ID)
+	r.last[run.TaskID] = run
+}
+
+// IsRunning checks if task has running instances
+func (r *Registry) IsRunning(taskID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, run := range r.running {
+		if run.TaskID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+// Running returns copy of running tasks ordered by start time
+func (r *Registry) Running() []Run {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedRuns(r.running)
+}
+
+// LastRuns returns copy of the last finished runs ordered by start time
+func (r *Registry) LastRuns() []Run {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedRuns(r.last)
+}
+
+func sortedRuns[K comparable](m map[K]*Run) []Run {
+	res := make([]Run, 0, len(m))
+	for _, run := range m {
+		res = append(res, *run)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Start.Before(res[j].Start)
+	})
+	return res
+}
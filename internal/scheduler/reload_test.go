@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"testing"
+	"weatherbot/internal/app"
+)
+
+func TestReloadTasks(t *testing.T) {
+	path := writeCrontab(t, `30 8 * * * weather Moscow
+0 9 * * * weather Yekaterinburg
+0 9 * * * weather Yekaterinburg
+`)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	a := &app.AppContext{Crontab: path, Logger: logger}
+
+	tasks, err := ParseConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := cron.New(cron.WithParser(cronParser))
+	entries := RunTasks(a, tasks, cr)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	moscow := entries[tasks[0].ID()]
+
+	// Moscow is unchanged, one of duplicates is removed, Kazan is added
+	err = os.WriteFile(path, []byte(`# comment moves lines
+0 9 * * * weather Yekaterinburg
+30 8 * * * weather Moscow
+@daily weather Kazan
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloadTasks(a, cr, entries)
+
+	if len(entries) != 3 || len(cr.Entries()) != 3 {
+		t.Fatalf("expected 3 entries, got %d (cron %d)", len(entries), len(cr.Entries()))
+	}
+	if entries[tasks[0].ID()].ID != moscow.ID {
+		t.Error("unchanged task must keep its cron entry")
+	}
+	if line := entries[tasks[0].ID()].Task.Line; line != 3 {
+		t.Errorf("unchanged task must have new line 3, got %d", line)
+	}
+	if _, found := entries[tasks[1].ID()+"-2"]; found {
+		t.Error("removed duplicate task must be deleted")
+	}
+}
@@ -1,94 +1,200 @@
 package scheduler
 
 import (
+	"errors"
 	"fmt"
 	"github.com/robfig/cron/v3"
-	"reflect"
+	"hash/fnv"
+	"os"
 	"strings"
+	"time"
 	"weatherbot/internal/app"
-	"weatherbot/internal/weather/providers"
+	"weatherbot/internal/command"
+	"weatherbot/internal/history"
 )
 
 // Task contains info about schedule task
-// Schedule in the same format as crontab
-// Command name is the key for cmdStorage map (see below)
+// Schedule in the same format as crontab. it may also have seconds field,
+// CRON_TZ= prefix or be a descriptor like @daily
+// Command is the command line from crontab. Name is the key in command registry
+// Line is the line number in crontab file
+// Overlap is policy for the case when previous run is not finished yet (--overlap=skip|queue|allow)
+// CatchUp is window for running missed task after downtime (--catchup=2h)
+// Jitter is max random delay of task start (--jitter=5m). JitterSet is true if option is given,
+// so --jitter=0 disables global TASK_JITTER for the task
 type Task struct {
-	Schedule string
-	Command  string
+	Line      int
+	Schedule  string
+	Command   string
+	Name      string
+	Args      *command.Args
+	Overlap   string
+	CatchUp   time.Duration
+	Jitter    time.Duration
+	JitterSet bool
 }
 
-type cmdMapping map[string]interface{}
-
-// cmdStorage contains commands for scheduled tasks
-var cmdStorage = cmdMapping{
-	"weather": providers.GetWeather,
-	"test":    test,
+// ID returns identifier of task. it is the same for the same schedule and command
+func (t Task) ID() string {
+	h := fnv.New64a()
+	h.Write([]byte(t.Schedule + "\x00" + t.Command))
+	return fmt.Sprintf("%016x", h.Sum64())
 }
 
-// Start main launcher
-func Start(app *app.AppContext) {
-	tasks, err := ParseConfig(app.Crontab)
+// Start main launcher. tasks are run only by the instance which holds the lock file
+// signals is channel registered for SIGINT, SIGTERM, SIGHUP and SIGUSR1 for the whole lifetime of process
+func Start(app *app.AppContext, signals <-chan os.Signal) {
+	defer removeStatus(app)
+
+	lk, ok := acquireLock(app, signals)
+	if !ok {
+		return
+	}
+	defer lk.Release()
+
+	tasks, err := loadTasks(app)
 	if err != nil {
 		app.Logger.Fatalf("Error reading crontab file %s: %v", app.Crontab, err)
 	}
 
-	cr := cron.New()
-	RunTasks(app, tasks, cr)
+	cr := cron.New(cron.WithParser(cronParser))
+	entries := RunTasks(app, tasks, cr)
 	cr.Start()
-	defer cr.Stop()
+	catchUp(app, entries, cr, time.Now())
+	writeStatus(app, cr, entries)
 
-	watchCrontabFile(app, cr)
+	// blocks until termination signal
+	watchCrontabFile(app, cr, entries, signals)
+	shutdown(app, cr, signals)
+}
+
+// loadTasks parse crontab and log wrong lines. error is returned only if file can't be read
+func loadTasks(app *app.AppContext) ([]Task, error) {
+	tasks, err := ParseConfig(app.Crontab)
+	var parseErrors ParseErrors
+	if errors.As(err, &parseErrors) {
+		for _, lineErr := range parseErrors {
+			app.Logger.Errorf("Crontab %v. Task is skipped", lineErr)
+		}
+		return tasks, nil
+	}
+	return tasks, err
+}
+
+// Entry task added to cron
+type Entry struct {
+	ID   cron.EntryID
+	Task Task
 }
 
 // RunTasks walks through crontab tasks and run command
-func RunTasks(app *app.AppContext, tasks []Task, cr *cron.Cron) {
-	for _, task := range tasks {
-		task := task // closure
-		_, err := cr.AddFunc(task.Schedule, func() {
-			go executeTask(app, task.Command)
-		})
+// returns cron entries of tasks by task key (see keyedTasks)
+func RunTasks(app *app.AppContext, tasks []Task, cr *cron.Cron) map[string]Entry {
+	entries := make(map[string]Entry, len(tasks))
+	for key, task := range keyedTasks(tasks) {
+		id, err := addTask(app, task, cr)
 		if err != nil {
-			app.Logger.Printf("Error adding cron task %s: %v", task.Schedule, err)
+			continue
+		}
+		entries[key] = Entry{ID: id, Task: task}
+	}
+	return entries
+}
+
+// addTask adds task to cron
+// cron runs every job in its own goroutine. overlapping runs are handled by job wrapper
+func addTask(app *app.AppContext, task Task, cr *cron.Cron) (cron.EntryID, error) {
+	logger := cronLogger{log: app.Logger, prefix: task.Command}
+	job := cron.NewChain(overlapWrapper(overlapPolicy(task), logger)).Then(cron.FuncJob(func() {
+		_ = executeTask(app, task, startDelay(task))
+	}))
+	id, err := cr.AddJob(task.Schedule, job)
+	if err != nil {
+		app.Logger.Printf("Error adding cron task %s: %v", task.Schedule, err)
+	}
+	return id, err
+}
+
+// keyedTasks returns tasks by key. key is task id, identical lines get suffix with number
+func keyedTasks(tasks []Task) map[string]Task {
+	res := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		key := task.ID()
+		for n := 2; ; n++ {
+			if _, found := res[key]; !found {
+				break
+			}
+			key = fmt.Sprintf("%s-%d", task.ID(), n)
 		}
+		res[key] = task
+	}
+	return res
+}
+
+// RunOnce runs command given in command line right away, the same way as scheduled task
+func RunOnce(app *app.AppContext, args []string) error {
+	task, err := commandTask("", strings.Join(args, " "), args)
+	if err != nil {
+		return err
 	}
+	return executeTask(app, task, 0)
 }
 
-// executeTask goroutine with real execution of command
-func executeTask(app *app.AppContext, cmd string) {
+// executeTask real execution of command. run is registered in registry of runs
+// and saved to history. start of command is delayed by given delay (see jitter)
+func executeTask(app *app.AppContext, task Task, delay time.Duration) (err error) {
+	run := runs.Begin(task, delay)
+	if delay > 0 && !waitDelay(app, task, run) {
+		return nil
+	}
+	record := newRecord(task, run.Start)
 	defer func() {
 		if r := recover(); r != nil {
-			app.Logger.Printf("Recovered from panic in task %s: %v", cmd, r)
+			err = fmt.Errorf("panic: %v", r)
+			app.Logger.Printf("Recovered from panic in task %s: %v", task.Command, r)
 		}
+		runs.Finish(run, err)
+		saveRecord(app, record, err)
 	}()
 
-	parts := strings.Fields(strings.Trim(cmd, `"`))
-	command := parts[0]
-	args := parts[1:]
-	_, _ = callFunc(app, command, args)
+	cmd, found := command.Get(task.Name)
+	if !found {
+		err = fmt.Errorf("unknown command %q", task.Name)
+		app.Logger.Errorf("Unknown command in task %s", task.Command)
+		return err
+	}
+	ctx := history.NewContext(app.Context, record)
+	if err = cmd.Run(ctx, app, task.Args); err != nil {
+		app.Logger.Errorf("Task %s failed: %v", task.Command, err)
+	}
+	return err
 }
 
-// callFunc call function with params via reflection
-func callFunc(app *app.AppContext, funcName string, params ...interface{}) (result interface{}, err error) {
-	f := reflect.ValueOf(cmdStorage[funcName])
-	if (len(params) + 1) != f.Type().NumIn() {
-		err = fmt.Errorf("the number of params is out of index. len:%d; num:%d", len(params), f.Type().NumIn())
-		return
+func newRecord(task Task, start time.Time) *history.Record {
+	record := &history.Record{
+		TaskID:  task.ID(),
+		Command: task.Command,
+		Name:    task.Name,
+		Start:   start,
 	}
-	in := make([]reflect.Value, len(params)+1)
-	// first param always app context
-	in[0] = reflect.ValueOf(app)
-	for k, param := range params {
-		in[k+1] = reflect.ValueOf(param)
+	if task.Args != nil {
+		record.Args = task.Args.Values
 	}
-
-	var res []reflect.Value
-	res = f.Call(in)
-	result = res[0].Interface()
-
-	return
+	return record
 }
 
-// test just for test
-func test(app *app.AppContext, args0 int) int {
-	return args0
+// saveRecord saves run to history if history is enabled
+func saveRecord(app *app.AppContext, record *history.Record, err error) {
+	record.Finish(err)
+	if app.History == nil {
+		return
+	}
+	if err := app.History.Add(record); err != nil {
+		app.Logger.Errorf("Failed to save task %s to history: %v", record.Command, err)
+	}
+	if record.Status == history.StatusSuccess {
+		if err := app.History.SetLastSuccess(record.TaskID, record.Start); err != nil {
+			app.Logger.Errorf("Failed to save last success of task %s: %v", record.Command, err)
+		}
+	}
 }
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"github.com/robfig/cron/v3"
+	"os"
+	"syscall"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// cancelTimeout how long to wait for tasks after cancel of root context
+const cancelTimeout = 5 * time.Second
+
+// shutdownTimeout returns SHUTDOWN_TIMEOUT from config
+func shutdownTimeout() time.Duration {
+	timeout, err := time.ParseDuration(config.GetConfigValue("SHUTDOWN_TIMEOUT"))
+	if err != nil || timeout < 0 {
+		return defaultShutdownTimeout
+	}
+	return timeout
+}
+
+// shutdown stops scheduler and waits for running tasks up to SHUTDOWN_TIMEOUT
+// then (or on the second signal) root context is cancelled, so provider calls,
+// rendering and uploads of the rest tasks are interrupted. SIGHUP and SIGUSR1 are ignored while waiting
+func shutdown(app *app.AppContext, cr *cron.Cron, signals <-chan os.Signal) {
+	// delayed runs are not started yet, so they are not waited for
+	stopDelayed()
+
+	done := make(chan struct{})
+	go func() {
+		<-cr.Stop().Done()
+		catchUpJobs.Wait()
+		close(done)
+	}()
+
+	timeout := shutdownTimeout()
+	if running := runs.Running(); len(running) > 0 {
+		app.Logger.Printf("Waiting up to %s for %d running task(s)", timeout, len(running))
+	}
+
+	deadline := time.After(timeout)
+wait:
+	for {
+		select {
+		case <-done:
+			app.Logger.Println("Scheduler stopped")
+			return
+		case <-deadline:
+			app.Logger.Println("Shutdown timeout exceeded. Cancelling running tasks")
+			break wait
+		case sig := <-signals:
+			if sig == syscall.SIGHUP || sig == syscall.SIGUSR1 {
+				app.Logger.Printf("Received signal %s while shutting down. Ignored", sig)
+				continue
+			}
+			app.Logger.Printf("Received signal %s again. Cancelling running tasks", sig)
+			break wait
+		}
+	}
+
+	if app.Cancel != nil {
+		app.Cancel()
+	}
+	select {
+	case <-done:
+		app.Logger.Println("Scheduler stopped")
+	case <-time.After(cancelTimeout):
+		app.Logger.Printf("%d task(s) did not stop in time", len(runs.Running()))
+	}
+}
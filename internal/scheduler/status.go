@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"weatherbot/config"
+	"weatherbot/internal/app"
+	"weatherbot/internal/lock"
+)
+
+// DefaultStatusFile default path to status file
+const DefaultStatusFile = "data/status.json"
+
+// TaskStatus scheduled task with its next run time and max random delay of start
+type TaskStatus struct {
+	Line     int
+	Schedule string
+	Command  string
+	Next     time.Time
+	Jitter   time.Duration
+}
+
+// Status state of running scheduler. it is written to status file on start, reload and SIGUSR1
+// Standby is set if instance waits for the lock held by another instance (Holder)
+type Status struct {
+	PID      int
+	Crontab  string
+	Updated  time.Time
+	Standby  bool
+	Holder   *lock.Holder
+	Tasks    []TaskStatus
+	Running  []Run
+	LastRuns []Run
+}
+
+// StatusFile returns path to status file of process with given pid. pid is added to name of STATUS_FILE,
+// so leader and standby instances with the same config do not overwrite status of each other
+func StatusFile(pid int) string {
+	path := config.GetConfigValue("STATUS_FILE")
+	if path == "" {
+		path = DefaultStatusFile
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), pid, ext)
+}
+
+// writeStatus writes current status of scheduler to status file
+func writeStatus(app *app.AppContext, cr *cron.Cron, entries map[string]Entry) {
+	status := &Status{
+		PID:      os.Getpid(),
+		Crontab:  app.Crontab,
+		Updated:  time.Now(),
+		Running:  runs.Running(),
+		LastRuns: runs.LastRuns(),
+	}
+	status.Holder, _ = lock.ReadHolder(LockFile())
+	for _, entry := range entries {
+		status.Tasks = append(status.Tasks, TaskStatus{
+			Line:     entry.Task.Line,
+			Schedule: entry.Task.Schedule,
+			Command:  entry.Task.Command,
+			Next:     cr.Entry(entry.ID).Next,
+			Jitter:   taskJitter(entry.Task),
+		})
+	}
+	sort.Slice(status.Tasks, func(i, j int) bool {
+		return status.Tasks[i].Line < status.Tasks[j].Line
+	})
+
+	if err := saveStatus(StatusFile(os.Getpid()), status); err != nil {
+		app.Logger.Errorf("Failed to write status file: %v", err)
+	}
+}
+
+// writeStandbyStatus writes status of instance which waits for the lock
+func writeStandbyStatus(app *app.AppContext, holder *lock.Holder) {
+	status := &Status{
+		PID:     os.Getpid(),
+		Crontab: app.Crontab,
+		Updated: time.Now(),
+		Standby: true,
+		Holder:  holder,
+	}
+	if err := saveStatus(StatusFile(os.Getpid()), status); err != nil {
+		app.Logger.Errorf("Failed to write status file: %v", err)
+	}
+}
+
+// removeStatus removes status file of process on exit
+func removeStatus(app *app.AppContext) {
+	if err := os.Remove(StatusFile(os.Getpid())); err != nil && !os.IsNotExist(err) {
+		app.Logger.Errorf("Failed to remove status file: %v", err)
+	}
+}
+
+// saveStatus writes status to temporary file and renames it, so reader never sees partial file
+func saveStatus(path string, status *Status) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadStatus reads status file
+func ReadStatus(path string) (*Status, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
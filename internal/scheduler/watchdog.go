@@ -4,73 +4,108 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/robfig/cron/v3"
 	"os"
-	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 	"weatherbot/internal/app"
 )
 
+const debounceDuration = 500 * time.Millisecond
+
 // watchCrontabFile inspect changes in crontab file and reread tasks
-func watchCrontabFile(ctx *app.AppContext, cr *cron.Cron) {
+// parent directory is watched, so atomic save (write to temp file and rename) is handled too
+// SIGHUP rereads tasks manually, SIGUSR1 writes status file. returns on termination signal
+func watchCrontabFile(ctx *app.AppContext, cr *cron.Cron, entries map[string]Entry, signals <-chan os.Signal) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		ctx.Logger.Fatal(err)
 	}
 	defer watcher.Close()
 
-	done := make(chan bool)
-	var lastModTime time.Time
-	const debounceDuration = 1 * time.Second
-
-	go func() {
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if !ok {
-					return
-				}
-				if event.Op&fsnotify.Write == fsnotify.Write {
-					stat, err := os.Stat(ctx.Crontab)
-					if err != nil {
-						ctx.Logger.Printf("Error stating crontab file %s: %v", ctx.Crontab, err)
-						continue
-					}
-					modTime := stat.ModTime()
-					if modTime.Sub(lastModTime) < debounceDuration {
-						continue
-					}
-					lastModTime = modTime
+	crontab, err := filepath.Abs(ctx.Crontab)
+	if err != nil {
+		ctx.Logger.Fatal(err)
+	}
+	if err := watcher.Add(filepath.Dir(crontab)); err != nil {
+		ctx.Logger.Fatal(err)
+	}
 
-					ctx.Logger.Println("Modified file:", event.Name)
-					cr.Stop()
-					tasks, err := ParseConfig(ctx.Crontab)
-					if err != nil {
-						ctx.Logger.Printf("Error reading crontab file %s: %v", ctx.Crontab, err)
-						continue
-					}
-					cr = cron.New()
-					RunTasks(ctx, tasks, cr)
-					cr.Start()
-				}
-			case err, ok := <-watcher.Errors:
-				if !ok {
-					return
-				}
-				ctx.Logger.Println("Error:", err)
+	// editors write file by several operations, so reload after the last event only
+	var debounce <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != crontab || event.Op == fsnotify.Chmod {
+				continue
+			}
+			ctx.Logger.Debugf("Crontab event: %s", event)
+			debounce = time.After(debounceDuration)
+		case <-debounce:
+			debounce = nil
+			ctx.Logger.Println("Modified file:", ctx.Crontab)
+			reloadTasks(ctx, cr, entries)
+			writeStatus(ctx, cr, entries)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			ctx.Logger.Println("Error:", err)
+		case sig := <-signals:
+			switch sig {
+			case syscall.SIGHUP:
+				ctx.Logger.Printf("Received signal %s. Reloading crontab", sig)
+				reloadTasks(ctx, cr, entries)
+				writeStatus(ctx, cr, entries)
+				continue
+			case syscall.SIGUSR1:
+				writeStatus(ctx, cr, entries)
+				continue
 			}
+			ctx.Logger.Printf("Received signal %s. Shutting down...", sig)
+			return
 		}
-	}()
+	}
+}
 
-	err = watcher.Add(ctx.Crontab)
+// reloadTasks rereads crontab and applies the difference to cron
+// unchanged tasks keep their cron entries and schedule, but their line numbers are updated.
+// only removed and added tasks are changed in cron
+func reloadTasks(ctx *app.AppContext, cr *cron.Cron, entries map[string]Entry) {
+	if _, err := os.Stat(ctx.Crontab); err != nil {
+		// file may be absent for a moment while it is being replaced
+		ctx.Logger.Printf("Crontab file %s is not available: %v. Tasks are not changed", ctx.Crontab, err)
+		return
+	}
+	tasks, err := loadTasks(ctx)
 	if err != nil {
-		ctx.Logger.Fatal(err)
+		ctx.Logger.Printf("Error reading crontab file %s: %v", ctx.Crontab, err)
+		return
 	}
 
-	// block main thread until termination signal
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-sigCh
-	ctx.Logger.Printf("Received signal %s. Shutting down...", sig)
+	newTasks := keyedTasks(tasks)
+	removed, added := 0, 0
+	for key, entry := range entries {
+		if _, found := newTasks[key]; !found {
+			cr.Remove(entry.ID)
+			delete(entries, key)
+			removed++
+		}
+	}
+	for key, task := range newTasks {
+		if entry, found := entries[key]; found {
+			entries[key] = Entry{ID: entry.ID, Task: task}
+			continue
+		}
+		id, err := addTask(ctx, task, cr)
+		if err != nil {
+			continue
+		}
+		entries[key] = Entry{ID: id, Task: task}
+		added++
+	}
 
-	close(done)
+	ctx.Logger.Printf("Crontab reloaded: %d added, %d removed, %d unchanged", added, removed, len(entries)-added)
 }
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultPath default path to database file
+const DefaultPath = "data/weatherbot.db"
+
+// openTimeout how long to wait for the file lock. database is locked by the process which opened it
+const openTimeout = 5 * time.Second
+
+// Store local embedded storage based on bbolt
+// database file is opened only for the time of transaction, so the running daemon
+// and commands like "weatherbot history" can use it at the same time
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New returns store with given database file
+func New(path string) *Store {
+	if path == "" {
+		path = DefaultPath
+	}
+	return &Store{path: path}
+}
+
+// Path returns path to database file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Update executes function in read-write transaction. bucket is created if not exists
+func (s *Store) Update(bucket string, fn func(b *bolt.Bucket) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open database %s: %w", s.path, err)
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return fn(b)
+	})
+}
+
+// View executes function in read-only transaction. function is not called if bucket does not exist
+func (s *Store) View(bucket string, fn func(b *bolt.Bucket) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: openTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open database %s: %w", s.path, err)
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return fn(b)
+	})
+}
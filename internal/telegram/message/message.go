@@ -10,16 +10,36 @@ import (
 	"time"
 	"weatherbot/i18n"
 	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/units"
 )
 
-func GenerateWeatherHtm(data *weather.WeatherData, templatePath string) (string, error) {
+// GenerateWeatherHtm generate html from template. language and units are taken from options
+func GenerateWeatherHtm(data *weather.WeatherData, templatePath string, opts *weather.Options) (string, error) {
 	t, err := template.New("base").Funcs(template.FuncMap{
 		"T": func(messageID string) string {
-			return i18n.Translate(messageID)
+			return i18n.TranslateTo(opts.Language, messageID)
 		},
 		"greaterThan": func(a, b float64) bool {
 			return a > b
 		},
+		"temp": func(value float64) float64 {
+			return units.Temperature(value, opts.Units)
+		},
+		"speed": func(value float64) float64 {
+			return units.Speed(value, opts.Units)
+		},
+		"precip": func(value float64) float64 {
+			return units.Precipitation(value, opts.Units)
+		},
+		"pressure": func(value float64) float64 {
+			return units.Pressure(value, opts.Pressure)
+		},
+		"unit": func(kind string) string {
+			if kind == "pressure" {
+				return units.PressureLabel(opts.Pressure)
+			}
+			return units.Label(kind, opts.Units)
+		},
 	}).ParseFiles(templatePath)
 	if err != nil {
 		return "", err
@@ -34,8 +54,10 @@ func GenerateWeatherHtm(data *weather.WeatherData, templatePath string) (string,
 	return tplBuffer.String(), nil
 }
 
-func RenderHTMLToImage(htmlContent string, outputPath string) error {
-	ctx, cancel := chromedp.NewContext(context.Background())
+// RenderHTMLToImage render html in headless browser and save screenshot to outputPath
+// browser is closed when ctx is done
+func RenderHTMLToImage(ctx context.Context, htmlContent string, outputPath string) error {
+	ctx, cancel := chromedp.NewContext(ctx)
 	defer cancel()
 
 	tmpFile, err := os.CreateTemp("", "*.html")
@@ -1,37 +1,60 @@
 package message
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 	"weatherbot/internal/app"
 	"weatherbot/internal/weather"
 )
 
-const templatePath = "templates/weather.html"
+const templateDir = "templates"
+
+// DefaultTemplate name of template used when task has no --template option
+const DefaultTemplate = "weather"
+
+// DailyTemplate name of template used for daily forecast when task has no --template option
+const DailyTemplate = "daily"
+
+// TemplatePath returns path to template file by its name
+func TemplatePath(name string) string {
+	return filepath.Join(templateDir, name+".html")
+}
 
 // SendMessageToTelegram send message to telegram with weather data
-func SendMessageToTelegram(app *app.AppContext, data *weather.WeatherData) {
+// chat, template, language and units are taken from options. returns id of sent message
+func SendMessageToTelegram(ctx context.Context, app *app.AppContext, data *weather.WeatherData, opts *weather.Options) (messageID int, err error) {
 	const method = "SendMessageToTelegram"
 	defer func() {
 		if r := recover(); r != nil {
 			app.Logger.Printf("Recovered from panic in %s: %v", method, r)
+			err = fmt.Errorf("%s. panic: %v", method, r)
 		}
 	}()
 
 	if data.CurrentData == nil || data.ForecastData == nil {
-		return
+		return 0, fmt.Errorf("%s. no weather data", method)
 	}
 
-	htmlContent, err := GenerateWeatherHtm(data, templatePath)
+	htmlContent, err := GenerateWeatherHtm(data, TemplatePath(opts.Template), opts)
 	if err != nil {
 		app.Logger.Printf("%s. Failed to generate HTML: %v", method, err)
-		return
+		return 0, err
+	}
+
+	if app.OutputDir != "" {
+		return 0, saveMessage(ctx, app.OutputDir, data, htmlContent)
 	}
 
 	tempFile, err := os.CreateTemp("", "weather_forecast_*.png")
 	if err != nil {
 		app.Logger.Printf("%s. Failed to create temporary file: %v", method, err)
-		return
+		return 0, err
 	}
 
 	defer func() {
@@ -39,13 +62,42 @@ func SendMessageToTelegram(app *app.AppContext, data *weather.WeatherData) {
 		os.Remove(tempFile.Name())
 	}()
 
-	if err := RenderHTMLToImage(htmlContent, tempFile.Name()); err != nil {
+	if err := RenderHTMLToImage(ctx, htmlContent, tempFile.Name()); err != nil {
 		app.Logger.Printf("%s. Failed to render HTML to image: %v", method, err)
-		return
+		return 0, err
+	}
+
+	// telegram api does not support context, so check it before upload
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%s. message is not sent: %w", method, err)
 	}
 
-	photo := tgbotapi.NewPhoto(app.ChatID, tgbotapi.FilePath(tempFile.Name()))
-	if _, err := app.TelegramBot.Bot.Send(photo); err != nil {
+	photo := tgbotapi.NewPhoto(opts.ChatID, tgbotapi.FilePath(tempFile.Name()))
+	msg, err := app.TelegramBot.Bot.Send(photo)
+	if err != nil {
 		app.Logger.Printf("%s. Telegram bot send error: %v", method, err)
+		return 0, err
+	}
+	return msg.MessageID, nil
+}
+
+// saveMessage dry run: writes rendered image and weather data as json to directory instead of sending
+func saveMessage(ctx context.Context, dir string, data *weather.WeatherData, htmlContent string) error {
+	const method = "saveMessage"
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("%s. %w", method, err)
+	}
+	name := filepath.Join(dir, fmt.Sprintf("%s_%s", strings.ReplaceAll(data.CurrentData.City, " ", "_"), time.Now().Format("20060102_150405")))
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s. %w", method, err)
+	}
+	if err := os.WriteFile(name+".json", content, 0644); err != nil {
+		return fmt.Errorf("%s. %w", method, err)
+	}
+	if err := RenderHTMLToImage(ctx, htmlContent, name+".png"); err != nil {
+		return fmt.Errorf("%s. failed to render HTML to image: %w", method, err)
 	}
+	return nil
 }
@@ -0,0 +1,66 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"os"
+	"path/filepath"
+	"time"
+	"weatherbot/internal/app"
+)
+
+// MaxTextLength max length of telegram text message
+const MaxTextLength = 4096
+
+// SendText sends text message to chat. returns id of sent message
+// in dry run mode message is written to output directory
+func SendText(ctx context.Context, app *app.AppContext, chatID int64, text string) (int, error) {
+	const method = "SendText"
+	if app.OutputDir != "" {
+		return 0, saveFile(app.OutputDir, "message.txt", []byte(text))
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%s. message is not sent: %w", method, err)
+	}
+
+	msg, err := app.TelegramBot.Bot.Send(tgbotapi.NewMessage(chatID, text))
+	if err != nil {
+		app.Logger.Printf("%s. Telegram bot send error: %v", method, err)
+		return 0, err
+	}
+	return msg.MessageID, nil
+}
+
+// SendFile sends content as document with given file name and caption. returns id of sent message
+// in dry run mode file is written to output directory
+func SendFile(ctx context.Context, app *app.AppContext, chatID int64, name string, content []byte, caption string) (int, error) {
+	const method = "SendFile"
+	if app.OutputDir != "" {
+		return 0, saveFile(app.OutputDir, name, content)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%s. message is not sent: %w", method, err)
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: content})
+	doc.Caption = caption
+	msg, err := app.TelegramBot.Bot.Send(doc)
+	if err != nil {
+		app.Logger.Printf("%s. Telegram bot send error: %v", method, err)
+		return 0, err
+	}
+	return msg.MessageID, nil
+}
+
+// saveFile writes file to directory. name gets time prefix, so files are not overwritten
+func saveFile(dir, name string, content []byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("saveFile. %w", err)
+	}
+	path := filepath.Join(dir, time.Now().Format("20060102_150405.000_")+name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("saveFile. %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package weather
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// alignTolerance max difference of row timestamps of different providers which are considered the same time
+const alignTolerance = 30 * time.Minute
+
+// Range min and max of value from several providers
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// Spread ranges of row values from several providers in aggregation mode
+// Providers is count of providers which have data for the row
+type Spread struct {
+	Temperature   Range
+	FeelsLike     Range
+	Pressure      Range
+	Humidity      Range
+	Clouds        Range
+	Precipitation Range
+	WindSpeed     Range
+	Providers     int
+}
+
+// Aggregate merges weather data of several providers into one
+// rows are aligned by timestamps of the first data, numeric fields are mean values of all providers
+// and their min/max are kept in Spread. text fields (weather description, sunrise) are taken from the first data
+// daily rows are aligned by date
+func Aggregate(data []*WeatherData) *WeatherData {
+	if len(data) == 0 {
+		return nil
+	}
+	base := data[0]
+	if len(data) == 1 {
+		return base
+	}
+
+	names := make([]string, 0, len(data))
+	for _, d := range data {
+		names = append(names, d.Provider)
+	}
+	res := &WeatherData{Provider: strings.Join(names, ", ")}
+	// air quality indexes are not averaged, the first provider which has it is used
+	for _, d := range data {
+		if d.AirQuality != nil {
+			res.AirQuality = d.AirQuality
+			break
+		}
+	}
+
+	if base.CurrentData != nil {
+		values := make([]float64, 0, len(data))
+		for _, d := range data {
+			if d.CurrentData != nil {
+				values = append(values, d.CurrentData.Weather)
+			}
+		}
+		mean, spread := aggregateValues(values)
+		res.CurrentData = &CurrentData{City: base.CurrentData.City, Weather: mean, Spread: &spread}
+	}
+
+	if base.ForecastData != nil {
+		forecast := *base.ForecastData
+		forecast.Rows = make([]Row, 0, len(base.ForecastData.Rows))
+		for _, row := range base.ForecastData.Rows {
+			rows := []Row{row}
+			for _, d := range data[1:] {
+				if d.ForecastData == nil {
+					continue
+				}
+				if aligned, found := alignRow(row.Timestamp, d.ForecastData.Rows); found {
+					rows = append(rows, aligned)
+				}
+			}
+			forecast.Rows = append(forecast.Rows, aggregateRows(rows))
+		}
+		forecast.Daily = aggregateDaily(data)
+		res.ForecastData = &forecast
+	}
+	return res
+}
+
+// alignRow returns row with the nearest timestamp within alignTolerance
+func alignRow(timestamp string, rows []Row) (Row, bool) {
+	t, err := time.ParseInLocation(time.DateTime, timestamp, time.Local)
+	if err != nil {
+		return Row{}, false
+	}
+	best, found := Row{}, false
+	bestDiff := alignTolerance + 1
+	for _, row := range rows {
+		rowTime, err := time.ParseInLocation(time.DateTime, row.Timestamp, time.Local)
+		if err != nil {
+			continue
+		}
+		diff := rowTime.Sub(t)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= alignTolerance && diff < bestDiff {
+			best, found, bestDiff = row, true, diff
+		}
+	}
+	return best, found
+}
+
+// aggregateRows returns the first row with mean numeric values of all rows
+func aggregateRows(rows []Row) Row {
+	res := rows[0]
+	spread := &Spread{Providers: len(rows)}
+	res.Temperature, spread.Temperature = aggregateField(rows, func(r Row) float64 { return r.Temperature })
+	res.FeelsLike, spread.FeelsLike = aggregateField(rows, func(r Row) float64 { return r.FeelsLike })
+	// pressure 0 is unknown (nws has no pressure), so it is not averaged with values of other providers
+	var pressure []float64
+	for _, row := range rows {
+		if row.Pressure > 0 {
+			pressure = append(pressure, row.Pressure)
+		}
+	}
+	res.Pressure, spread.Pressure = aggregateValues(pressure)
+	res.Precipitation, spread.Precipitation = aggregateField(rows, func(r Row) float64 { return r.Precipitation })
+	res.Wind.Speed, spread.WindSpeed = aggregateField(rows, func(r Row) float64 { return r.Wind.Speed })
+
+	var humidity, clouds float64
+	humidity, spread.Humidity = aggregateField(rows, func(r Row) float64 { return float64(r.Humidity) })
+	clouds, spread.Clouds = aggregateField(rows, func(r Row) float64 { return float64(r.Clouds) })
+	res.Humidity = int(math.Round(humidity))
+	res.Clouds = int(math.Round(clouds))
+
+	res.Spread = spread
+	return res
+}
+
+func aggregateField(rows []Row, value func(Row) float64) (float64, Range) {
+	values := make([]float64, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, value(row))
+	}
+	return aggregateValues(values)
+}
+
+// aggregateDaily merges daily rows of providers by dates of the first data
+// temperatures, precipitation and wind are mean values of providers which have the date,
+// condition and probability of precipitation are taken from the first data
+func aggregateDaily(data []*WeatherData) []DailyRow {
+	base := data[0].ForecastData.Daily
+	if len(base) == 0 {
+		return base
+	}
+	result := make([]DailyRow, 0, len(base))
+	for _, day := range base {
+		var tempMin, tempMax, precipitation, wind []float64
+		for _, d := range data {
+			if d.ForecastData == nil {
+				continue
+			}
+			for _, other := range d.ForecastData.Daily {
+				if other.Date == day.Date {
+					tempMin = append(tempMin, other.TempMin)
+					tempMax = append(tempMax, other.TempMax)
+					precipitation = append(precipitation, other.Precipitation)
+					wind = append(wind, other.WindMax)
+					break
+				}
+			}
+		}
+		day.TempMin, _ = aggregateValues(tempMin)
+		day.TempMax, _ = aggregateValues(tempMax)
+		day.Precipitation, _ = aggregateValues(precipitation)
+		day.WindMax, _ = aggregateValues(wind)
+		result = append(result, day)
+	}
+	return result
+}
+
+// aggregateValues returns mean rounded to 0.1 and min/max of values
+func aggregateValues(values []float64) (float64, Range) {
+	if len(values) == 0 {
+		return 0, Range{}
+	}
+	sum := 0.0
+	spread := Range{Min: values[0], Max: values[0]}
+	for _, v := range values {
+		sum += v
+		spread.Min = math.Min(spread.Min, v)
+		spread.Max = math.Max(spread.Max, v)
+	}
+	return math.Round(sum/float64(len(values))*10) / 10, spread
+}
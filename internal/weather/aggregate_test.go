@@ -0,0 +1,102 @@
+package weather
+
+import "testing"
+
+func TestAggregate(t *testing.T) {
+	owm := &WeatherData{
+		Provider:    "openweathermap",
+		CurrentData: &CurrentData{City: "Moscow", Weather: 10},
+		ForecastData: &ForecastData{Rows: []Row{
+			{Timestamp: "2024-07-01 12:00:00", Temperature: 20, Humidity: 50, Weather: "clear", Wind: Wind{Speed: 2}},
+			{Timestamp: "2024-07-01 15:00:00", Temperature: 22, Humidity: 40, Weather: "clouds"},
+		}},
+	}
+	weatherapi := &WeatherData{
+		Provider:    "weatherapi",
+		CurrentData: &CurrentData{City: "Moscow", Weather: 13},
+		ForecastData: &ForecastData{Rows: []Row{
+			{Timestamp: "2024-07-01 12:00:00", Temperature: 23, Humidity: 61, Wind: Wind{Speed: 4}},
+			{Timestamp: "2024-07-01 13:00:00", Temperature: 25, Humidity: 60},
+		}},
+	}
+
+	res := Aggregate([]*WeatherData{owm, weatherapi})
+	if res.Provider != "openweathermap, weatherapi" {
+		t.Errorf("unexpected provider %q", res.Provider)
+	}
+	if res.CurrentData.Weather != 11.5 || *res.CurrentData.Spread != (Range{Min: 10, Max: 13}) {
+		t.Errorf("unexpected current weather %v %v", res.CurrentData.Weather, res.CurrentData.Spread)
+	}
+	if len(res.ForecastData.Rows) != 2 {
+		t.Fatalf("expected rows of the first provider, got %d", len(res.ForecastData.Rows))
+	}
+
+	row := res.ForecastData.Rows[0]
+	if row.Temperature != 21.5 || row.Spread.Temperature != (Range{Min: 20, Max: 23}) {
+		t.Errorf("unexpected temperature %v %v", row.Temperature, row.Spread.Temperature)
+	}
+	if row.Humidity != 56 || row.Wind.Speed != 3 || row.Weather != "clear" || row.Spread.Providers != 2 {
+		t.Errorf("unexpected row %+v", row)
+	}
+
+	// 15:00 has no row of the second provider within tolerance
+	row = res.ForecastData.Rows[1]
+	if row.Temperature != 22 || row.Spread.Providers != 1 {
+		t.Errorf("unexpected not aligned row %+v", row)
+	}
+
+	if Aggregate([]*WeatherData{owm}) != owm {
+		t.Error("data of single provider must be returned as is")
+	}
+}
+
+func TestAggregateDaily(t *testing.T) {
+	owm := &WeatherData{Provider: "openweathermap", ForecastData: &ForecastData{Daily: []DailyRow{
+		{Date: "2024-07-01", TempMin: 14, TempMax: 22, Precipitation: 1, Weather: "rain", WindMax: 4, Pop: "60"},
+		{Date: "2024-07-02", TempMin: 12, TempMax: 20, Weather: "clear", WindMax: 3, Pop: "0"},
+	}}}
+	openmeteo := &WeatherData{Provider: "openmeteo", ForecastData: &ForecastData{Daily: []DailyRow{
+		{Date: "2024-07-01", TempMin: 15, TempMax: 25, Precipitation: 2.5, Weather: "slight rain", WindMax: 5, Pop: "80"},
+		{Date: "2024-07-03", TempMin: 10, TempMax: 18},
+	}}}
+	hourly := &WeatherData{Provider: "nws", ForecastData: &ForecastData{Rows: []Row{{Timestamp: "2024-07-01 12:00:00"}}}}
+
+	tests := []struct {
+		name string
+		data []*WeatherData
+		want []DailyRow
+	}{
+		{"merged", []*WeatherData{owm, openmeteo}, []DailyRow{
+			{Date: "2024-07-01", TempMin: 14.5, TempMax: 23.5, Precipitation: 1.8, Weather: "rain", WindMax: 4.5, Pop: "60"},
+			{Date: "2024-07-02", TempMin: 12, TempMax: 20, Weather: "clear", WindMax: 3, Pop: "0"},
+		}},
+		{"provider without daily", []*WeatherData{owm, hourly}, owm.ForecastData.Daily},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := Aggregate(tt.data).ForecastData.Daily
+			if len(days) != len(tt.want) {
+				t.Fatalf("expected %d days, got %+v", len(tt.want), days)
+			}
+			for i, day := range days {
+				if day != tt.want[i] {
+					t.Errorf("day %d: expected %+v, got %+v", i, tt.want[i], day)
+				}
+			}
+		})
+	}
+}
+
+func TestAggregateUnknownPressure(t *testing.T) {
+	owm := &WeatherData{Provider: "openweathermap", ForecastData: &ForecastData{Rows: []Row{
+		{Timestamp: "2024-07-01 12:00:00", Pressure: 1010},
+	}}}
+	nws := &WeatherData{Provider: "nws", ForecastData: &ForecastData{Rows: []Row{
+		{Timestamp: "2024-07-01 12:00:00"},
+	}}}
+
+	row := Aggregate([]*WeatherData{nws, owm}).ForecastData.Rows[0]
+	if row.Pressure != 1010 || row.Spread.Pressure != (Range{Min: 1010, Max: 1010}) {
+		t.Errorf("unknown pressure must not be averaged, got %v %v", row.Pressure, row.Spread.Pressure)
+	}
+}
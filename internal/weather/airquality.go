@@ -0,0 +1,127 @@
+package weather
+
+import (
+	"context"
+	"math"
+)
+
+// AirQuality concentrations of pollutants (μg/m³) and air quality indexes
+// indexes are calculated from concentrations, so they are the same for all providers
+type AirQuality struct {
+	PM25        float64
+	PM10        float64
+	O3          float64
+	NO2         float64
+	EuropeanAQI int
+	USAQI       int
+}
+
+// AirQualityInterface provider which returns air quality for city
+type AirQualityInterface interface {
+	GetAirQuality(context.Context, *CityInfo) (*AirQuality, error)
+}
+
+// breakpoint concentration and index value of the bound of index band
+type breakpoint struct {
+	c     float64
+	index float64
+}
+
+// European AQI bands of EEA: 0-20 good, 20-40 fair, 40-60 moderate, 60-80 poor, 80-100 very poor, 100+ extremely poor
+var europeanBreakpoints = map[string][]breakpoint{
+	"pm25": {{0, 0}, {10, 20}, {20, 40}, {25, 60}, {50, 80}, {75, 100}, {800, 500}},
+	"pm10": {{0, 0}, {20, 20}, {40, 40}, {50, 60}, {100, 80}, {150, 100}, {1200, 500}},
+	"o3":   {{0, 0}, {50, 20}, {100, 40}, {130, 60}, {240, 80}, {380, 100}, {800, 500}},
+	"no2":  {{0, 0}, {40, 20}, {90, 40}, {120, 60}, {230, 80}, {340, 100}, {1000, 500}},
+}
+
+// US EPA AQI. ozone and nitrogen dioxide bands are in ppb
+var usBreakpoints = map[string][]breakpoint{
+	"pm25": {{0, 0}, {9, 50}, {35.4, 100}, {55.4, 150}, {125.4, 200}, {225.4, 300}, {325.4, 500}},
+	"pm10": {{0, 0}, {54, 50}, {154, 100}, {254, 150}, {354, 200}, {424, 300}, {604, 500}},
+	"o3":   {{0, 0}, {54, 50}, {70, 100}, {85, 150}, {105, 200}, {200, 300}, {604, 500}},
+	"no2":  {{0, 0}, {53, 50}, {100, 100}, {360, 150}, {649, 200}, {1249, 300}, {2049, 500}},
+}
+
+// μg/m³ in 1 ppb at 25°C
+const (
+	o3PerPpb  = 1.96
+	no2PerPpb = 1.88
+)
+
+// NewAirQuality returns air quality with indexes calculated from concentrations in μg/m³
+// index is the max of indexes of pollutants
+func NewAirQuality(pm25, pm10, o3, no2 float64) *AirQuality {
+	return &AirQuality{
+		PM25: math.Round(pm25*10) / 10,
+		PM10: math.Round(pm10*10) / 10,
+		O3:   math.Round(o3*10) / 10,
+		NO2:  math.Round(no2*10) / 10,
+		EuropeanAQI: maxIndex(europeanBreakpoints, map[string]float64{
+			"pm25": pm25, "pm10": pm10, "o3": o3, "no2": no2,
+		}),
+		USAQI: maxIndex(usBreakpoints, map[string]float64{
+			"pm25": pm25, "pm10": pm10, "o3": o3 / o3PerPpb, "no2": no2 / no2PerPpb,
+		}),
+	}
+}
+
+// EuropeanCategory returns category of European AQI
+func (a *AirQuality) EuropeanCategory() string {
+	switch {
+	case a.EuropeanAQI <= 20:
+		return "Good"
+	case a.EuropeanAQI <= 40:
+		return "Fair"
+	case a.EuropeanAQI <= 60:
+		return "Moderate"
+	case a.EuropeanAQI <= 80:
+		return "Poor"
+	case a.EuropeanAQI <= 100:
+		return "Very poor"
+	default:
+		return "Extremely poor"
+	}
+}
+
+// USCategory returns category of US AQI
+func (a *AirQuality) USCategory() string {
+	switch {
+	case a.USAQI <= 50:
+		return "Good"
+	case a.USAQI <= 100:
+		return "Moderate"
+	case a.USAQI <= 150:
+		return "Unhealthy for sensitive groups"
+	case a.USAQI <= 200:
+		return "Unhealthy"
+	case a.USAQI <= 300:
+		return "Very unhealthy"
+	default:
+		return "Hazardous"
+	}
+}
+
+func maxIndex(breakpoints map[string][]breakpoint, values map[string]float64) int {
+	result := 0
+	for name, value := range values {
+		if index := interpolate(breakpoints[name], value); index > result {
+			result = index
+		}
+	}
+	return result
+}
+
+// interpolate returns index of concentration by linear interpolation inside its band
+func interpolate(bands []breakpoint, c float64) int {
+	if c <= 0 {
+		return 0
+	}
+	for i := 1; i < len(bands); i++ {
+		lo, hi := bands[i-1], bands[i]
+		if c <= hi.c {
+			return int(math.Round(lo.index + (c-lo.c)*(hi.index-lo.index)/(hi.c-lo.c)))
+		}
+	}
+	return int(bands[len(bands)-1].index)
+}
@@ -0,0 +1,28 @@
+package weather
+
+import "testing"
+
+func TestNewAirQuality(t *testing.T) {
+	tests := []struct {
+		name                 string
+		pm25, pm10, o3, no2  float64
+		european, us         int
+		euCategory, category string
+	}{
+		{"clean", 2.5, 5, 40, 10, 16, 19, "Good", "Good"},
+		{"pm25", 30, 40, 60, 20, 64, 90, "Poor", "Moderate"},
+		{"ozone", 5, 10, 200, 15, 73, 193, "Poor", "Unhealthy"},
+		{"smoke", 150, 180, 20, 60, 141, 225, "Extremely poor", "Very unhealthy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aq := NewAirQuality(tt.pm25, tt.pm10, tt.o3, tt.no2)
+			if aq.EuropeanAQI != tt.european || aq.USAQI != tt.us {
+				t.Errorf("expected indexes %d/%d, got %d/%d", tt.european, tt.us, aq.EuropeanAQI, aq.USAQI)
+			}
+			if aq.EuropeanCategory() != tt.euCategory || aq.USCategory() != tt.category {
+				t.Errorf("expected categories %q/%q, got %q/%q", tt.euCategory, tt.category, aq.EuropeanCategory(), aq.USCategory())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package weather
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Alert severe weather warning from provider
+// ID is provider alert id. providers without ids build it from event, area and start of alert
+// Cancelled is set when provider publishes cancellation of alert
+type Alert struct {
+	ID          string
+	Provider    string
+	City        string
+	Event       string
+	Severity    string
+	Headline    string
+	Description string
+	Sender      string
+	Start       time.Time
+	End         time.Time
+	Cancelled   bool
+}
+
+// AlertsInterface provider which returns active alerts for city
+type AlertsInterface interface {
+	GetAlerts(context.Context, string) ([]Alert, error)
+}
+
+// AlertID builds id of alert from its fields for providers which have no alert ids
+func AlertID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,82 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// DailyRow forecast for one day. Weather is the dominant condition of the day
+type DailyRow struct {
+	Date          string
+	TempMin       float64
+	TempMax       float64
+	Precipitation float64
+	Weather       string
+	WindMax       float64
+	Pop           string
+}
+
+// DailyForecastInterface provider which returns daily (multi-day) forecast
+// returned forecast data has Daily rows instead of hourly Rows
+type DailyForecastInterface interface {
+	GetDailyForecast(context.Context, *CityInfo) (*ForecastData, error)
+}
+
+// DailyWeatherDataInterface weather provider with daily forecast
+type DailyWeatherDataInterface interface {
+	WeatherDataInterface
+	DailyForecastInterface
+}
+
+// DailyFromRows aggregates hourly or 3-hourly rows into days of given location
+// rows are grouped by date, condition which occurs most often is the dominant one
+func DailyFromRows(rows []Row, loc *time.Location) []DailyRow {
+	if loc == nil {
+		loc = time.Local
+	}
+	var days []DailyRow
+	var counts []map[string]int
+	for _, row := range rows {
+		t, err := time.ParseInLocation(time.DateTime, row.Timestamp, time.Local)
+		if err != nil {
+			continue
+		}
+		date := t.In(loc).Format(time.DateOnly)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, DailyRow{Date: date, TempMin: row.Temperature, TempMax: row.Temperature})
+			counts = append(counts, map[string]int{})
+		}
+		day := &days[len(days)-1]
+		day.TempMin = math.Min(day.TempMin, row.Temperature)
+		day.TempMax = math.Max(day.TempMax, row.Temperature)
+		day.Precipitation = math.Round((day.Precipitation+row.Precipitation)*10) / 10
+		day.WindMax = math.Max(day.WindMax, row.Wind.Speed)
+		day.Pop = maxPop(day.Pop, row.Pop)
+
+		dayCounts := counts[len(counts)-1]
+		dayCounts[row.Weather]++
+		// on equal counts the earlier condition is kept
+		if day.Weather == "" || dayCounts[row.Weather] > dayCounts[day.Weather] {
+			day.Weather = row.Weather
+		}
+	}
+	return days
+}
+
+// maxPop returns max of probabilities of precipitation in percents. empty value is unknown probability
+func maxPop(a, b string) string {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA != nil && errB != nil:
+		return ""
+	case errA != nil:
+		return b
+	case errB != nil:
+		return a
+	}
+	return fmt.Sprintf("%d", max(x, y))
+}
@@ -0,0 +1,71 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyFromRows(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	rows := []Row{
+		{Timestamp: "2024-07-01 18:00:00", Temperature: 20, Precipitation: 0.2, Weather: "rain", Pop: "40", Wind: Wind{Speed: 3}},
+		{Timestamp: "2024-07-01 21:00:00", Temperature: 17, Precipitation: 1.1, Weather: "clear", Pop: "70", Wind: Wind{Speed: 5}},
+		{Timestamp: "2024-07-01 22:00:00", Temperature: 16, Weather: "clear", Wind: Wind{Speed: 4}},
+		{Timestamp: "2024-07-02 03:00:00", Temperature: 12, Weather: "fog", Pop: "10"},
+		{Timestamp: "wrong", Temperature: 40},
+	}
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want []DailyRow
+	}{
+		{"utc", time.UTC, []DailyRow{
+			{Date: "2024-07-01", TempMin: 16, TempMax: 20, Precipitation: 1.3, Weather: "clear", WindMax: 5, Pop: "70"},
+			{Date: "2024-07-02", TempMin: 12, TempMax: 12, Weather: "fog", Pop: "10"},
+		}},
+		// 21:00 UTC is the next day in +03:00, conditions have equal counts, so the earlier one wins
+		{"offset", time.FixedZone("MSK", 3*3600), []DailyRow{
+			{Date: "2024-07-01", TempMin: 20, TempMax: 20, Precipitation: 0.2, Weather: "rain", WindMax: 3, Pop: "40"},
+			{Date: "2024-07-02", TempMin: 12, TempMax: 17, Precipitation: 1.1, Weather: "clear", WindMax: 5, Pop: "70"},
+		}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := rows
+			if tt.want == nil {
+				input = nil
+			}
+			days := DailyFromRows(input, tt.loc)
+			if len(days) != len(tt.want) {
+				t.Fatalf("expected %d days, got %+v", len(tt.want), days)
+			}
+			for i, day := range days {
+				if day != tt.want[i] {
+					t.Errorf("day %d: expected %+v, got %+v", i, tt.want[i], day)
+				}
+			}
+		})
+	}
+}
+
+func TestMaxPop(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"30", "70", "70"},
+		{"70", "30", "70"},
+		{"", "20", "20"},
+		{"20", "", "20"},
+		{"", "", ""},
+		{"n/a", "5", "5"},
+	}
+	for _, tt := range tests {
+		if got := maxPop(tt.a, tt.b); got != tt.want {
+			t.Errorf("maxPop(%q, %q): expected %q, got %q", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
@@ -12,13 +12,39 @@ import (
 
 const timeout = 45 * time.Second
 
+// forecastFunc requests forecast and sends it to channel, like GetWeatherDataForecast of provider
+type forecastFunc func(context.Context, *weather.CityInfo, *sync.WaitGroup, chan<- *weather.ForecastData, chan<- error)
+
 // GetWeatherDataImpl implement of GetWeatherData by given weather provider
-func GetWeatherDataImpl[T weather.WeatherDataInterface](ctx context.Context, city string, w T, ch chan<- *weather.WeatherData, wg *sync.WaitGroup) {
-	defer wg.Done()
+// current weather and forecast are requested concurrently
+func GetWeatherDataImpl[T weather.WeatherDataInterface](ctx context.Context, city string, w T) (*weather.WeatherData, error) {
+	return getWeatherData(ctx, city, w, w.GetWeatherDataForecast)
+}
 
-	ch1 := make(chan *weather.CurrentData)
-	ch2 := make(chan *weather.ForecastData)
-	errCh := make(chan error)
+// GetDailyWeatherDataImpl returns current weather and daily forecast of given weather provider
+func GetDailyWeatherDataImpl[T weather.DailyWeatherDataInterface](ctx context.Context, city string, w T) (*weather.WeatherData, error) {
+	daily := func(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("panic in GetDailyForecast: %v", r)
+			}
+			wg.Done()
+		}()
+		data, err := w.GetDailyForecast(ctx, cityInfo)
+		if err != nil {
+			errCh <- fmt.Errorf("GetDailyForecast. %w", err)
+			return
+		}
+		ch <- data
+	}
+	return getWeatherData(ctx, city, w, daily)
+}
+
+func getWeatherData(ctx context.Context, city string, w weather.WeatherDataInterface, forecast forecastFunc) (*weather.WeatherData, error) {
+	// channels are buffered so api-calls never block when result is not read because of cancelled context
+	ch1 := make(chan *weather.CurrentData, 1)
+	ch2 := make(chan *weather.ForecastData, 1)
+	errCh := make(chan error, 2)
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -31,18 +57,18 @@ func GetWeatherDataImpl[T weather.WeatherDataInterface](ctx context.Context, cit
 
 	// city main contains coordinates in form Yekaterinburg[51.456 60.560]
 	// so take coordinates from name or make geolocation api-call
-	cityInfo, err := utils.GetCityInfo(city, w)
-	if err != nil {
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	cityInfo, err := utils.GetCityInfo(ctx, city, w)
+	if err != nil {
+		return nil, err
+	}
+
 	wg1 := &sync.WaitGroup{}
 	wg1.Add(2)
-	go w.GetCurrentWeatherData(cityInfo, wg1, ch1, errCh)
-	go w.GetWeatherDataForecast(cityInfo, wg1, ch2, errCh)
+	go w.GetCurrentWeatherData(ctx, cityInfo, wg1, ch1, errCh)
+	go forecast(ctx, cityInfo, wg1, ch2, errCh)
 
 	go func() {
 		wg1.Wait()
@@ -51,6 +77,20 @@ func GetWeatherDataImpl[T weather.WeatherDataInterface](ctx context.Context, cit
 		close(errCh)
 	}()
 
+	// air quality is optional, so its error is only logged
+	airQuality := make(chan *weather.AirQuality, 1)
+	if aq, ok := any(w).(weather.AirQualityInterface); ok {
+		go func() {
+			data, err := aq.GetAirQuality(ctx, cityInfo)
+			if err != nil {
+				logger.Logger().Printf("Failed to get air quality of %s: %v", cityInfo.Name, err)
+			}
+			airQuality <- data
+		}()
+	} else {
+		airQuality <- nil
+	}
+
 	// build result weather: current and forecast
 	var combinedErr error
 	result := &weather.WeatherData{}
@@ -87,10 +127,12 @@ func GetWeatherDataImpl[T weather.WeatherDataInterface](ctx context.Context, cit
 	}
 
 	if combinedErr != nil {
-		logger.Logger().Errorf("Encountered errors: %v\n", combinedErr)
-		return
+		return nil, combinedErr
 	}
-
-	// output result to data weather channel
-	ch <- result
+	select {
+	case result.AirQuality = <-airQuality:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return result, nil
 }
@@ -8,15 +8,15 @@ import (
 
 // WeatherDataInterface main interface
 type WeatherDataInterface interface {
-	GetWeatherData(context.Context, string, chan<- *WeatherData, *sync.WaitGroup)
-	GetCurrentWeatherData(*CityInfo, *sync.WaitGroup, chan<- *CurrentData, chan<- error)
-	GetWeatherDataForecast(*CityInfo, *sync.WaitGroup, chan<- *ForecastData, chan<- error)
+	GetWeatherData(context.Context, string) (*WeatherData, error)
+	GetCurrentWeatherData(context.Context, *CityInfo, *sync.WaitGroup, chan<- *CurrentData, chan<- error)
+	GetWeatherDataForecast(context.Context, *CityInfo, *sync.WaitGroup, chan<- *ForecastData, chan<- error)
 	GeoCoderInterface
 }
 
 // GeoCoderInterface interface uses while working with geolocation api
 type GeoCoderInterface interface {
-	GetGeoCodeCityInfo(string) (*CityInfo, error)
+	GetGeoCodeCityInfo(context.Context, string) (*CityInfo, error)
 	CacheInterface
 }
 
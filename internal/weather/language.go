@@ -0,0 +1,28 @@
+package weather
+
+import (
+	"strings"
+	"weatherbot/i18n"
+)
+
+// ProviderLanguage returns language code of provider for locale like "en", "pt-BR" or "ru_RU"
+// codes maps locale (lowercase, with dash) or its base language to code of provider api
+func ProviderLanguage(locale string, codes map[string]string) (string, bool) {
+	locale = strings.ReplaceAll(strings.ToLower(locale), "_", "-")
+	if code, found := codes[locale]; found {
+		return code, true
+	}
+	base, _, _ := strings.Cut(locale, "-")
+	code, found := codes[base]
+	return code, found
+}
+
+// TranslateConditions translates weather conditions of forecast which provider returns in English
+func TranslateConditions(data *ForecastData, language string) {
+	for i := range data.Rows {
+		data.Rows[i].Weather = i18n.TranslateTo(language, data.Rows[i].Weather)
+	}
+	for i := range data.Daily {
+		data.Daily[i].Weather = i18n.TranslateTo(language, data.Daily[i].Weather)
+	}
+}
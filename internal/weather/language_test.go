@@ -0,0 +1,25 @@
+package weather
+
+import "testing"
+
+func TestProviderLanguage(t *testing.T) {
+	codes := map[string]string{"en": "en", "ru": "ru", "uk": "ua", "zh": "zh_cn", "zh-tw": "zh_tw"}
+	tests := []struct {
+		locale, code string
+		found        bool
+	}{
+		{"ru", "ru", true},
+		{"ru_RU", "ru", true},
+		{"uk", "ua", true},
+		{"zh-TW", "zh_tw", true},
+		{"zh_CN", "zh_cn", true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		code, found := ProviderLanguage(tt.locale, codes)
+		if code != tt.code || found != tt.found {
+			t.Errorf("%q: expected %q/%v, got %q/%v", tt.locale, tt.code, tt.found, code, found)
+		}
+	}
+}
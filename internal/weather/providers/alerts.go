@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"weatherbot/internal/app"
+	"weatherbot/internal/weather"
+)
+
+// GetAlerts returns active alerts for city in given language and name of provider which returned them
+// providers of chain without alerts support are skipped, the next one is tried if provider fails
+func GetAlerts(ctx context.Context, app *app.AppContext, city string, chain []string, lang string) ([]weather.Alert, string, error) {
+	var errs []error
+	for _, name := range chain {
+		info, found := weather.GetProvider(name)
+		if !found || !info.Has(weather.CapabilityAlerts) {
+			continue
+		}
+		provider, err := weather.NewProvider(name, app.Cache, app.Logger, lang)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		alertsProvider, ok := provider.(weather.AlertsInterface)
+		if !ok {
+			continue
+		}
+		alerts, err := alertsProvider.GetAlerts(ctx, city)
+		if err == nil {
+			return alerts, name, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		app.Logger.Errorf("Alerts provider %s failed for city %s: %v", name, city, err)
+	}
+	if len(errs) == 0 {
+		return nil, "", errors.New("no providers with alerts support in chain")
+	}
+	return nil, "", errors.Join(errs...)
+}
@@ -0,0 +1,11 @@
+package providers
+
+// weather providers register themselves in weather provider registry on init
+// to add new provider implement weather.WeatherDataInterface and import its package here
+import (
+	_ "weatherbot/internal/weather/providers/metno"
+	_ "weatherbot/internal/weather/providers/nws"
+	_ "weatherbot/internal/weather/providers/openmeteo"
+	_ "weatherbot/internal/weather/providers/openweathermap"
+	_ "weatherbot/internal/weather/providers/weatherapi"
+)
@@ -2,65 +2,84 @@ package providers
 
 import (
 	"context"
-	"github.com/patrickmn/go-cache"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 	"weatherbot/config"
 	"weatherbot/internal/app"
-	"weatherbot/internal/logger"
+	"weatherbot/internal/history"
 	"weatherbot/internal/telegram/message"
 	"weatherbot/internal/weather"
-	"weatherbot/internal/weather/providers/openweathermap"
-	"weatherbot/internal/weather/providers/weatherapi"
+	"weatherbot/internal/weather/handler"
+	"weatherbot/utils"
 )
 
-const providerOpenweathermap = "openweathermap"
-const providerWeatherapi = "weatherapi"
-
 // GetWeather get current and forecast weather for given cities
-// and send int to telegram chat
-func GetWeather(app *app.AppContext, cities []string) (res []*weather.WeatherData) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+// and send int to telegram chat. chain of providers and chat are taken from options
+// for every city providers are tried in order until one of them returns data
+// in aggregation mode data of all providers is requested and merged
+func GetWeather(ctx context.Context, app *app.AppContext, cities []string, opts *weather.Options) (res []*weather.WeatherData) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*60)
 	defer cancel()
 
 	wg := &sync.WaitGroup{}
-	// chanData weather data
+	// chanData weather data. it is closed when all providers calls are finished
 	chanData := make(chan *weather.WeatherData)
 	// chanMessage channel for sending message to telegram
 	chanMessage := make(chan *weather.WeatherData)
-	once := &sync.Once{}
-	closeDataChan := func(ch chan *weather.WeatherData) {
-		once.Do(func() {
-			close(ch)
-		})
-	}
+	// sent is closed when all messages are sent
+	sent := make(chan struct{})
 	defer func() {
-		closeDataChan(chanData)
 		close(chanMessage)
+		<-sent
 	}()
 
+	record := history.FromContext(ctx)
 	sendMessageFunc := func(data *weather.WeatherData) {
-		message.SendMessageToTelegram(app, data)
+		messageID, err := message.SendMessageToTelegram(ctx, app, data, opts)
+		if err != nil {
+			record.AddOutcome(cityName(data), err)
+			return
+		}
+		record.AddOutcome(cityName(data), nil, messageID)
 	}
-	go worker(sendMessageFunc, chanMessage)
+	go func() {
+		worker(sendMessageFunc, chanMessage)
+		close(sent)
+	}()
 
-	provider := getProvider(app.Cache)
+	getData := getCityWeather
+	if opts.Aggregate {
+		getData = getAggregatedWeather
+	}
 	for _, city := range cities {
 		wg.Add(1)
-		go provider.GetWeatherData(ctx, city, chanData, wg)
+		go func(city string) {
+			defer wg.Done()
+			data, err := getData(ctx, app, city, opts)
+			if err != nil {
+				record.AddOutcome(parsedCityName(city), err)
+				return
+			}
+			select {
+			case chanData <- data:
+			case <-ctx.Done():
+			}
+		}(city)
 	}
 
 	go func() {
 		wg.Wait()
 		// close data channel. when closed it will stop cycle below
-		closeDataChan(chanData)
+		close(chanData)
 	}()
 
 	done := false
 	for !done {
 		select {
 		case <-ctx.Done():
-			closeDataChan(chanData)
 			done = true
 		case data, ok := <-chanData:
 			if !ok {
@@ -73,30 +92,149 @@ func GetWeather(app *app.AppContext, cities []string) (res []*weather.WeatherDat
 		}
 	}
 
+	addMissingOutcomes(record, cities, res)
 	return
 }
 
-// getProvider depends on config setting
-func getProvider(cache *cache.Cache) (provider weather.WeatherDataInterface) {
-	log := logger.Logger()
-	prov := config.GetConfigValue("WEATHER_PROVIDER")
-	switch prov {
-	case providerOpenweathermap:
-		provider = &openweathermap.OpenWeatherMap{
-			APIKey: config.GetApiKey(),
-			Cache:  cache,
-			Logger: log,
+// getCityWeather returns weather of city from the first provider of chain which succeeds
+func getCityWeather(ctx context.Context, app *app.AppContext, city string, opts *weather.Options) (*weather.WeatherData, error) {
+	var errs []error
+	for _, name := range opts.Providers {
+		data, err := getProviderWeather(ctx, app, city, name, opts)
+		if err == nil {
+			return data, nil
 		}
-	case providerWeatherapi:
-		provider = &weatherapi.WeatherAPI{
-			APIKey: config.GetApiKey(),
-			Cache:  cache,
-			Logger: log,
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		if ctx.Err() != nil {
+			break
 		}
-	default:
-		log.Println("Unknown weather provider:", prov)
+		app.Logger.Errorf("Weather provider %s failed for city %s: %v", name, city, err)
 	}
-	return
+	if len(errs) == 0 {
+		return nil, errors.New("no weather providers")
+	}
+	return nil, errors.Join(errs...)
+}
+
+// getAggregatedWeather requests weather of city from all providers of chain concurrently
+// and merges their data. providers which fail are skipped
+func getAggregatedWeather(ctx context.Context, app *app.AppContext, city string, opts *weather.Options) (*weather.WeatherData, error) {
+	chain := opts.Providers
+	results := make([]*weather.WeatherData, len(chain))
+	errs := make([]error, len(chain))
+	wg := &sync.WaitGroup{}
+	for i, name := range chain {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = getProviderWeather(ctx, app, city, name, opts)
+		}()
+	}
+	wg.Wait()
+
+	var data []*weather.WeatherData
+	var failed []error
+	for i, name := range chain {
+		if errs[i] != nil {
+			app.Logger.Errorf("Weather provider %s failed for city %s: %v", name, city, errs[i])
+			failed = append(failed, fmt.Errorf("%s: %w", name, errs[i]))
+			continue
+		}
+		data = append(data, results[i])
+	}
+	if len(data) == 0 {
+		if len(failed) == 0 {
+			return nil, errors.New("no weather providers")
+		}
+		return nil, errors.Join(failed...)
+	}
+	return weather.Aggregate(data), nil
+}
+
+// getProviderWeather returns weather of city from given provider in language of task
+// multi-day forecast is returned instead of hourly one if task is daily
+func getProviderWeather(ctx context.Context, app *app.AppContext, city, name string, opts *weather.Options) (*weather.WeatherData, error) {
+	provider, err := weather.NewProvider(name, app.Cache, app.Logger, opts.Language)
+	if err != nil {
+		return nil, err
+	}
+	var data *weather.WeatherData
+	if opts.Daily {
+		dailyProvider, ok := provider.(weather.DailyWeatherDataInterface)
+		if !ok {
+			return nil, fmt.Errorf("provider %s does not support daily forecast", name)
+		}
+		data, err = handler.GetDailyWeatherDataImpl(ctx, city, dailyProvider)
+	} else {
+		data, err = provider.GetWeatherData(ctx, city)
+	}
+	if err != nil {
+		return nil, err
+	}
+	data.Provider = name
+	return data, nil
+}
+
+// addMissingOutcomes adds failed outcome for cities which have no weather data
+func addMissingOutcomes(record *history.Record, cities []string, res []*weather.WeatherData) {
+	received := make(map[string]bool, len(res))
+	for _, data := range res {
+		received[cityName(data)] = true
+	}
+	for _, city := range cities {
+		name := parsedCityName(city)
+		if !received[name] && !record.HasOutcome(name) {
+			record.AddOutcome(name, errors.New("weather data not received"))
+		}
+	}
+}
+
+// parsedCityName returns name of city without coordinates
+func parsedCityName(city string) string {
+	if cityInfo, err := utils.ParseCity(city); err == nil {
+		return cityInfo.Name
+	}
+	return city
+}
+
+func cityName(data *weather.WeatherData) string {
+	if data.CurrentData == nil {
+		return ""
+	}
+	return data.CurrentData.City
+}
+
+// Validate checks global chain of weather providers from config. it is called on startup
+// so empty chain, unknown provider or missing api key is reported before tasks are run
+func Validate() error {
+	chain := Chain("")
+	if len(chain) == 0 {
+		return fmt.Errorf("WEATHER_PROVIDERS is not set (available: %s)", strings.Join(weather.ProviderNames(), ", "))
+	}
+	for _, provider := range chain {
+		if err := weather.ValidateProvider(provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Chain returns ordered list of providers from comma separated value
+// if value is empty, WEATHER_PROVIDERS from config is used, then WEATHER_PROVIDER
+func Chain(value string) []string {
+	if value == "" {
+		value = config.GetConfigValue("WEATHER_PROVIDERS")
+	}
+	if value == "" {
+		value = config.GetConfigValue("WEATHER_PROVIDER")
+	}
+	var chain []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			chain = append(chain, name)
+		}
+	}
+	return chain
 }
 
 // worker read data from data channel and execute given function with data
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"io"
+	"strings"
+	"testing"
+	"weatherbot/internal/app"
+	"weatherbot/internal/weather"
+)
+
+// fakeProvider returns given data or error
+type fakeProvider struct {
+	weather.WeatherDataInterface
+	data *weather.WeatherData
+	err  error
+}
+
+func (p *fakeProvider) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return p.data, p.err
+}
+
+func registerFake(name string, data *weather.WeatherData, err error) {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name: name,
+		Factory: func(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+			return &fakeProvider{data: data, err: err}, nil
+		},
+	})
+}
+
+func TestGetCityWeatherFallback(t *testing.T) {
+	registerFake("fake-down", nil, errors.New("unreachable"))
+	registerFake("fake-up", &weather.WeatherData{CurrentData: &weather.CurrentData{City: "Moscow"}}, nil)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	app := &app.AppContext{Cache: cache.New(cache.NoExpiration, cache.NoExpiration), Logger: log}
+
+	data, err := getCityWeather(context.Background(), app, "Moscow", &weather.Options{Providers: []string{"fake-down", "fake-up"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.Provider != "fake-up" {
+		t.Errorf("expected data from fake-up, got %q", data.Provider)
+	}
+
+	_, err = getCityWeather(context.Background(), app, "Moscow", &weather.Options{Providers: []string{"fake-down", "unknown"}})
+	if err == nil {
+		t.Fatal("expected error when all providers fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	registerFake("fake-valid", nil, nil)
+	defer viper.Set("WEATHER_PROVIDERS", "")
+
+	viper.Set("WEATHER_PROVIDERS", "")
+	err := Validate()
+	if err == nil {
+		t.Fatal("expected error for empty chain of providers")
+	}
+	if !strings.Contains(err.Error(), "fake-valid") {
+		t.Errorf("expected available providers in error, got %v", err)
+	}
+
+	viper.Set("WEATHER_PROVIDERS", "fake-valid")
+	if err := Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
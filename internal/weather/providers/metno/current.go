@@ -0,0 +1,65 @@
+package metno
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+	"weatherbot/internal/weather"
+)
+
+// GetCurrentWeatherData get current weather from data provider
+// current weather is the item of locationforecast for the current hour
+func (m *MetNorway) GetCurrentWeatherData(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
+	const method = "GetCurrentWeatherData"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	body, err := m.fetch(ctx, m.ForecastUrl, m.GetUrlParams(cityInfo))
+	if err != nil {
+		errCh <- fmt.Errorf("%s. %w", method, err)
+		return
+	}
+
+	var forecastResponse ForecastResponse
+	err = json.Unmarshal(body, &forecastResponse)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+		return
+	}
+	item := currentItem(forecastResponse.Properties.Timeseries, now())
+	if item == nil {
+		errCh <- fmt.Errorf("%s. empty forecast", method)
+		return
+	}
+
+	data := &weather.CurrentData{
+		City:    cityInfo.Name,
+		Weather: math.Round(item.Data.Instant.Details.AirTemperature),
+	}
+
+	ch <- data
+}
+
+// currentItem returns the latest item that is not in the future
+// cached response may start some hours before the current time
+func currentItem(timeseries []TimeSeries, currentTime time.Time) *TimeSeries {
+	if len(timeseries) == 0 {
+		return nil
+	}
+	item := &timeseries[0]
+	for i := range timeseries {
+		if timeseries[i].Time.After(currentTime) {
+			break
+		}
+		item = &timeseries[i]
+	}
+	return item
+}
@@ -0,0 +1,157 @@
+package metno
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+	"weatherbot/internal/weather"
+)
+
+const cntRows = 18
+
+// sunTimeLayout layout of time in sunrise api
+const sunTimeLayout = "2006-01-02T15:04Z07:00"
+
+// GetWeatherDataForecast get forecast from data provider
+func (m *MetNorway) GetWeatherDataForecast(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
+	const method = "GetWeatherDataForecast"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	body, err := m.fetch(ctx, m.ForecastUrl, m.GetUrlParams(cityInfo))
+	if err != nil {
+		errCh <- fmt.Errorf("%s. %w", method, err)
+		return
+	}
+
+	var forecastResponse ForecastResponse
+	err = json.Unmarshal(body, &forecastResponse)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+		return
+	}
+
+	data := convertForecast(&forecastResponse, now(), cntRows)
+	// forecast is useful without sunrise, so its error is only logged
+	if err := m.setSunTimes(ctx, cityInfo, data); err != nil {
+		m.Logger.Printf("%s. failed to get sunrise: %v", method, err)
+	}
+	weather.TranslateConditions(data, m.Language)
+
+	ch <- data
+}
+
+// convertForecast converts at most limit items of timeseries from the current hour to forecast data
+func convertForecast(resp *ForecastResponse, currentTime time.Time, limit int) *weather.ForecastData {
+	data := &weather.ForecastData{}
+	var lastTime time.Time
+	for _, item := range resp.Properties.Timeseries {
+		// the current hour is kept
+		if item.Time.Add(time.Hour).Before(currentTime) || len(data.Rows) >= limit {
+			continue
+		}
+		details := item.Data.Instant.Details
+		row := weather.Row{
+			Timestamp:   getLocalTime(item.Time),
+			Temperature: math.Round(details.AirTemperature),
+			// locationforecast has no apparent temperature
+			FeelsLike: math.Round(details.AirTemperature),
+			Pressure:  details.AirPressureAtSeaLevel,
+			Humidity:  int(math.Round(details.RelativeHumidity)),
+			Clouds:    int(math.Round(details.CloudAreaFraction)),
+			Wind: weather.Wind{
+				Speed: details.WindSpeed,
+				Deg:   int(math.Round(details.WindFromDirection)),
+				Gust:  details.WindSpeedOfGust,
+			},
+		}
+		// hourly items have next_1_hours, the rest of forecast has only next_6_hours
+		period := item.Data.Next1Hours
+		if period == nil {
+			period = item.Data.Next6Hours
+		}
+		if period != nil {
+			row.Weather = symbolDescription(period.Summary.SymbolCode)
+			row.Precipitation = period.Details.PrecipitationAmount
+			if pop := period.Details.ProbabilityOfPrecipitation; pop != nil {
+				row.Pop = fmt.Sprintf("%.0f", *pop)
+			}
+		}
+		data.Rows = append(data.Rows, row)
+		lastTime = item.Time
+	}
+	if !lastTime.IsZero() {
+		data.Days = math.Ceil(lastTime.Sub(currentTime).Hours() / 24)
+	}
+	return data
+}
+
+// GetDailyForecast returns daily forecast aggregated from the whole timeseries (about 9 days)
+// hourly items have precipitation of the next hour and the rest ones of the next 6 hours, so their sum is daily amount
+func (m *MetNorway) GetDailyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*weather.ForecastData, error) {
+	const method = "GetDailyForecast"
+
+	body, err := m.fetch(ctx, m.ForecastUrl, m.GetUrlParams(cityInfo))
+	if err != nil {
+		return nil, fmt.Errorf("%s. %w", method, err)
+	}
+
+	var forecastResponse ForecastResponse
+	if err := json.Unmarshal(body, &forecastResponse); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+
+	timeseries := forecastResponse.Properties.Timeseries
+	data := convertForecast(&forecastResponse, now(), len(timeseries))
+	data.Daily = weather.DailyFromRows(data.Rows, nil)
+	data.Rows = nil
+	data.Days = float64(len(data.Daily))
+	if err := m.setSunTimes(ctx, cityInfo, data); err != nil {
+		m.Logger.Printf("%s. failed to get sunrise: %v", method, err)
+	}
+	weather.TranslateConditions(data, m.Language)
+	return data, nil
+}
+
+// setSunTimes sets sunrise and sunset of today from sunrise api
+func (m *MetNorway) setSunTimes(ctx context.Context, cityInfo *weather.CityInfo, data *weather.ForecastData) error {
+	query := m.GetUrlParams(cityInfo)
+	(*query)["date"] = now().UTC().Format(time.DateOnly)
+	body, err := m.fetch(ctx, m.SunriseUrl, query)
+	if err != nil {
+		return err
+	}
+
+	var sunResponse SunriseResponse
+	if err := json.Unmarshal(body, &sunResponse); err != nil {
+		return err
+	}
+	// there is no sunrise or sunset during polar day and night
+	if event := sunResponse.Properties.Sunrise; event != nil {
+		data.Sunrise = formatSunTime(event.Time)
+	}
+	if event := sunResponse.Properties.Sunset; event != nil {
+		data.Sunset = formatSunTime(event.Time)
+	}
+	return nil
+}
+
+func formatSunTime(value string) string {
+	t, err := time.Parse(sunTimeLayout, value)
+	if err != nil {
+		return ""
+	}
+	return getLocalTime(t)
+}
+
+func getLocalTime(t time.Time) string {
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,71 @@
+package metno
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/handler"
+	"weatherbot/internal/weather/providers/openmeteo"
+)
+
+// Name name of provider in config and crontab
+const Name = "metno"
+
+// userAgentConfig config key with User-Agent. api.met.no requires identifying User-Agent with contact info
+const userAgentConfig = "METNO_USER_AGENT"
+
+const defaultUserAgent = "weatherbot/1.0 github.com/smirnov-a/weatherbot"
+
+const (
+	forecastUrl = "https://api.met.no/weatherapi/locationforecast/2.0/complete"
+	sunriseUrl  = "https://api.met.no/weatherapi/sunrise/3.0/sun"
+)
+
+func init() {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name:         Name,
+		Factory:      newProvider,
+		ConfigKeys:   []weather.ConfigKey{{Name: userAgentConfig}},
+		Capabilities: []weather.Capability{weather.CapabilityCurrent, weather.CapabilityForecast, weather.CapabilityDaily},
+	})
+}
+
+// MetNorway provider of MET Norway Locationforecast 2.0 (yr.no). api key is not required
+// responses are cached until they expire (Expires header) and then requested with If-Modified-Since
+type MetNorway struct {
+	UserAgent   string
+	ForecastUrl string
+	SunriseUrl  string
+	Cache       *cache.Cache
+	Logger      *logrus.Logger
+	Language    string
+}
+
+func newProvider(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+	userAgent := cfg.Get(userAgentConfig)
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	return &MetNorway{
+		UserAgent:   userAgent,
+		ForecastUrl: forecastUrl,
+		SunriseUrl:  sunriseUrl,
+		Cache:       cfg.Cache,
+		Logger:      cfg.Logger,
+		Language:    cfg.Language,
+	}, nil
+}
+
+func (m *MetNorway) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return handler.GetWeatherDataImpl(ctx, city, m)
+}
+
+func (m *MetNorway) GetCacheInstance() *cache.Cache {
+	return m.Cache
+}
+
+// GetGeoCodeCityInfo MET Norway has no geocoding api, so open-meteo geocoding is used
+func (m *MetNorway) GetGeoCodeCityInfo(ctx context.Context, city string) (*weather.CityInfo, error) {
+	return openmeteo.GeoCode(ctx, city, m.Logger)
+}
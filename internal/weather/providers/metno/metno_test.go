@@ -0,0 +1,148 @@
+package metno
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"weatherbot/internal/weather/providers/providertest"
+)
+
+const lastModified = "Mon, 01 Jul 2024 08:47:21 GMT"
+
+// testServer serves recorded responses of api.met.no from testdata
+// forecast expires in 30 minutes after current time of provider
+type testServer struct {
+	*httptest.Server
+	requests    atomic.Int32
+	notModified atomic.Int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	server := &testServer{}
+	serve := func(w http.ResponseWriter, r *http.Request, name string) {
+		if !strings.HasPrefix(r.Header.Get("User-Agent"), "weatherbot") {
+			t.Errorf("request without identifying User-Agent: %q", r.Header.Get("User-Agent"))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Expires", now().Add(30*time.Minute).UTC().Format(http.TimeFormat))
+		w.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			server.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		providertest.ServeFixture(t, w, name, "application/json")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/locationforecast/2.0/complete", func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+		if r.URL.Query().Get("lat") != "59.9139" || r.URL.Query().Get("lon") != "10.7522" {
+			t.Errorf("unexpected forecast query: %s", r.URL.RawQuery)
+		}
+		serve(w, r, "forecast.json")
+	})
+	mux.HandleFunc("/sunrise/3.0/sun", func(w http.ResponseWriter, r *http.Request) {
+		serve(w, r, "sunrise.json")
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetWeatherData(t *testing.T) {
+	current := time.Date(2024, 7, 1, 10, 10, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	server := newTestServer(t)
+	provider := &MetNorway{
+		UserAgent:   defaultUserAgent,
+		ForecastUrl: server.URL + "/locationforecast/2.0/complete",
+		SunriseUrl:  server.URL + "/sunrise/3.0/sun",
+		Cache:       cache.New(cache.NoExpiration, cache.NoExpiration),
+		Logger:      providertest.Logger(),
+	}
+
+	data, err := provider.GetWeatherData(context.Background(), "Oslo[59.9139 10.7522]")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// current weather and forecast use the same response
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("expected 1 forecast request, got %d", n)
+	}
+
+	if data.CurrentData.City != "Oslo" || data.CurrentData.Weather != 12 {
+		t.Errorf("unexpected current data %+v", data.CurrentData)
+	}
+	forecast := data.ForecastData
+	sunrise := time.Date(2024, 7, 1, 1, 57, 0, 0, time.UTC).Local().Format(time.DateTime)
+	if forecast.Sunrise != sunrise {
+		t.Errorf("expected sunrise %s, got %s", sunrise, forecast.Sunrise)
+	}
+	if len(forecast.Rows) != cntRows {
+		t.Fatalf("expected %d rows, got %d", cntRows, len(forecast.Rows))
+	}
+
+	// the first row is the current hour 10:00 UTC
+	row := forecast.Rows[0]
+	if row.Timestamp != time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC).Local().Format(time.DateTime) {
+		t.Errorf("unexpected first row time %s", row.Timestamp)
+	}
+	if row.Temperature != 12 || row.Pressure != 1009.9 || row.Humidity != 85 || row.Clouds != 47 {
+		t.Errorf("unexpected values of row %+v", row)
+	}
+	if row.Weather != "partly cloudy" || row.Pop != "1" || row.Wind.Speed != 4.4 || row.Wind.Deg != 255 || row.Wind.Gust != 8.8 {
+		t.Errorf("unexpected weather of row %+v", row)
+	}
+	if row := forecast.Rows[3]; row.Weather != "rain" || row.Precipitation != 1.2 {
+		t.Errorf("unexpected rain row %+v", row)
+	}
+
+	// response is not expired yet, so it is taken from cache
+	if _, err := provider.GetWeatherData(context.Background(), "Oslo[59.9139 10.7522]"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("expected cached response, got %d requests", n)
+	}
+
+	// expired response is requested with If-Modified-Since and reused on 304
+	current = current.Add(time.Hour)
+	data, err = provider.GetWeatherData(context.Background(), "Oslo[59.9139 10.7522]")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := server.requests.Load(); n != 2 {
+		t.Errorf("expected conditional request, got %d requests", n)
+	}
+	if server.notModified.Load() == 0 {
+		t.Error("expected 304 Not Modified response")
+	}
+	if len(data.ForecastData.Rows) != cntRows || data.ForecastData.Rows[0].Timestamp != time.Date(2024, 7, 1, 11, 0, 0, 0, time.UTC).Local().Format(time.DateTime) {
+		t.Errorf("unexpected forecast from cached response %+v", data.ForecastData.Rows[0])
+	}
+}
+
+func TestSymbolDescription(t *testing.T) {
+	tests := map[string]string{
+		"clearsky_night":                 "clear sky",
+		"heavyrainshowers_day":           "heavy rain showers",
+		"snow":                           "snow",
+		"lightsnowshowers_polartwilight": "light snow showers",
+		"unknownsymbol":                  "unknownsymbol",
+	}
+	for code, want := range tests {
+		if got := symbolDescription(code); got != want {
+			t.Errorf("symbolDescription(%q) = %q; want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package metno
+
+import (
+	"fmt"
+	"weatherbot/internal/weather"
+)
+
+// GetUrlParams returns map with parameters for api call
+// api accepts coordinates with at most 4 decimals
+func (m *MetNorway) GetUrlParams(cityInfo *weather.CityInfo) *map[string]string {
+	params := map[string]string{}
+	if cityInfo != nil && cityInfo.HasCoords {
+		params["lat"] = fmt.Sprintf("%.4f", cityInfo.Latitude)
+		params["lon"] = fmt.Sprintf("%.4f", cityInfo.Longitude)
+	}
+	return &params
+}
+
+func (m *MetNorway) GetGeoCodingParams(city string) *map[string]string {
+	return &map[string]string{}
+}
@@ -0,0 +1,86 @@
+package metno
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"weatherbot/utils"
+)
+
+// cacheTTL how long expired response is kept in cache for conditional requests
+const cacheTTL = 24 * time.Hour
+
+// cachedResponse response of api. it is used without request until Expires
+type cachedResponse struct {
+	Body         []byte
+	Expires      time.Time
+	LastModified string
+}
+
+// urlLocks locks requests by url
+var urlLocks utils.KeyLock
+
+// now current time. it is replaced in tests
+var now = time.Now
+
+// fetch returns body of api response. response is cached until its Expires header,
+// then it is requested with If-Modified-Since and cached body is reused on 304 Not Modified
+func (m *MetNorway) fetch(ctx context.Context, url string, query *map[string]string) ([]byte, error) {
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         url,
+		QueryParams: query,
+		Headers:     &map[string]string{"User-Agent": m.UserAgent},
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	key := "metno_" + req.URL.String()
+	defer urlLocks.Lock(key)()
+
+	var cached *cachedResponse
+	if data, found := m.Cache.Get(key); found {
+		cached = data.(*cachedResponse)
+		if now().Before(cached.Expires) {
+			return cached.Body, nil
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+	defer response.Body.Close()
+
+	entry := &cachedResponse{
+		Expires:      expires(response),
+		LastModified: response.Header.Get("Last-Modified"),
+	}
+	if response.StatusCode == http.StatusNotModified && cached != nil {
+		entry.Body = cached.Body
+		if entry.LastModified == "" {
+			entry.LastModified = cached.LastModified
+		}
+	} else if entry.Body, err = io.ReadAll(response.Body); err != nil {
+		return nil, fmt.Errorf("error read response: %w", err)
+	}
+	m.Cache.Set(key, entry, cacheTTL)
+	return entry.Body, nil
+}
+
+// expires returns time from Expires header. response without it is not cached
+func expires(response *http.Response) time.Time {
+	t, err := http.ParseTime(response.Header.Get("Expires"))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
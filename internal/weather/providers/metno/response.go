@@ -0,0 +1,70 @@
+package metno
+
+import "time"
+
+type ForecastResponse struct {
+	Properties Properties `json:"properties"`
+}
+
+type Properties struct {
+	Meta       Meta         `json:"meta"`
+	Timeseries []TimeSeries `json:"timeseries"`
+}
+
+type Meta struct {
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TimeSeries struct {
+	Time time.Time `json:"time"`
+	Data Data      `json:"data"`
+}
+
+type Data struct {
+	Instant     Instant `json:"instant"`
+	Next1Hours  *Period `json:"next_1_hours"`
+	Next6Hours  *Period `json:"next_6_hours"`
+	Next12Hours *Period `json:"next_12_hours"`
+}
+
+type Instant struct {
+	Details InstantDetails `json:"details"`
+}
+
+type InstantDetails struct {
+	AirPressureAtSeaLevel float64 `json:"air_pressure_at_sea_level"`
+	AirTemperature        float64 `json:"air_temperature"`
+	CloudAreaFraction     float64 `json:"cloud_area_fraction"`
+	RelativeHumidity      float64 `json:"relative_humidity"`
+	WindFromDirection     float64 `json:"wind_from_direction"`
+	WindSpeed             float64 `json:"wind_speed"`
+	WindSpeedOfGust       float64 `json:"wind_speed_of_gust"`
+}
+
+// Period forecast for the next hours after time of item
+type Period struct {
+	Summary Summary       `json:"summary"`
+	Details PeriodDetails `json:"details"`
+}
+
+type Summary struct {
+	SymbolCode string `json:"symbol_code"`
+}
+
+type PeriodDetails struct {
+	PrecipitationAmount        float64  `json:"precipitation_amount"`
+	ProbabilityOfPrecipitation *float64 `json:"probability_of_precipitation"`
+}
+
+type SunriseResponse struct {
+	Properties SunProperties `json:"properties"`
+}
+
+type SunProperties struct {
+	Sunrise *SunEvent `json:"sunrise"`
+	Sunset  *SunEvent `json:"sunset"`
+}
+
+type SunEvent struct {
+	Time string `json:"time"`
+}
@@ -0,0 +1,57 @@
+package metno
+
+import "strings"
+
+// symbols descriptions of MET Norway symbol codes without variant suffix (_day, _night, _polartwilight)
+var symbols = map[string]string{
+	"clearsky":                     "clear sky",
+	"fair":                         "fair",
+	"partlycloudy":                 "partly cloudy",
+	"cloudy":                       "cloudy",
+	"fog":                          "fog",
+	"lightrain":                    "light rain",
+	"rain":                         "rain",
+	"heavyrain":                    "heavy rain",
+	"lightrainshowers":             "light rain showers",
+	"rainshowers":                  "rain showers",
+	"heavyrainshowers":             "heavy rain showers",
+	"lightrainandthunder":          "light rain and thunder",
+	"rainandthunder":               "rain and thunder",
+	"heavyrainandthunder":          "heavy rain and thunder",
+	"lightrainshowersandthunder":   "light rain showers and thunder",
+	"rainshowersandthunder":        "rain showers and thunder",
+	"heavyrainshowersandthunder":   "heavy rain showers and thunder",
+	"lightsleet":                   "light sleet",
+	"sleet":                        "sleet",
+	"heavysleet":                   "heavy sleet",
+	"lightsleetshowers":            "light sleet showers",
+	"sleetshowers":                 "sleet showers",
+	"heavysleetshowers":            "heavy sleet showers",
+	"lightsleetandthunder":         "light sleet and thunder",
+	"sleetandthunder":              "sleet and thunder",
+	"heavysleetandthunder":         "heavy sleet and thunder",
+	"lightssleetshowersandthunder": "light sleet showers and thunder",
+	"sleetshowersandthunder":       "sleet showers and thunder",
+	"heavysleetshowersandthunder":  "heavy sleet showers and thunder",
+	"lightsnow":                    "light snow",
+	"snow":                         "snow",
+	"heavysnow":                    "heavy snow",
+	"lightsnowshowers":             "light snow showers",
+	"snowshowers":                  "snow showers",
+	"heavysnowshowers":             "heavy snow showers",
+	"lightsnowandthunder":          "light snow and thunder",
+	"snowandthunder":               "snow and thunder",
+	"heavysnowandthunder":          "heavy snow and thunder",
+	"lightssnowshowersandthunder":  "light snow showers and thunder",
+	"snowshowersandthunder":        "snow showers and thunder",
+	"heavysnowshowersandthunder":   "heavy snow showers and thunder",
+}
+
+// symbolDescription returns description of symbol code like "partlycloudy_day"
+func symbolDescription(code string) string {
+	base, _, _ := strings.Cut(code, "_")
+	if description, found := symbols[base]; found {
+		return description
+	}
+	return code
+}
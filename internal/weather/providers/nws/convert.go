@@ -0,0 +1,56 @@
+package nws
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// directions compass points of wind direction
+var directions = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// convertTemperature converts temperature to celsius. api returns fahrenheit by default
+func convertTemperature(value float64, unit string) float64 {
+	if unit == "F" {
+		value = (value - 32) * 5 / 9
+	}
+	return math.Round(value)
+}
+
+// parseWindSpeed converts wind speed like "10 mph" or "5 to 10 mph" to m/s
+// for a range the upper value is taken
+func parseWindSpeed(value string) float64 {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return 0
+	}
+	speed, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+	if err != nil {
+		return 0
+	}
+	switch fields[len(fields)-1] {
+	case "mph":
+		speed *= 0.44704
+	case "km/h":
+		speed /= 3.6
+	}
+	return math.Round(speed*10) / 10
+}
+
+// windDegree converts compass point to degrees
+func windDegree(direction string) int {
+	for i, d := range directions {
+		if d == direction {
+			return int(float64(i) * 22.5)
+		}
+	}
+	return 0
+}
+
+// percent returns value of percent or 0 when it is unknown
+func percent(v Value) int {
+	if v.Value == nil {
+		return 0
+	}
+	return int(math.Round(*v.Value))
+}
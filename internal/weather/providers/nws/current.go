@@ -0,0 +1,51 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"weatherbot/internal/weather"
+)
+
+// GetCurrentWeatherData get current weather from data provider
+// current weather is the period of hourly forecast for the current hour
+func (n *NWS) GetCurrentWeatherData(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
+	const method = "GetCurrentWeatherData"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	forecast, err := n.getHourlyForecast(ctx, cityInfo)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. %w", method, err)
+		return
+	}
+	period := currentPeriod(forecast.Properties.Periods)
+	if period == nil {
+		errCh <- fmt.Errorf("%s. empty forecast", method)
+		return
+	}
+
+	ch <- &weather.CurrentData{
+		City:    cityInfo.Name,
+		Weather: convertTemperature(period.Temperature, period.TemperatureUnit),
+	}
+}
+
+// currentPeriod returns the period which contains current time or the first one
+func currentPeriod(periods []Period) *Period {
+	if len(periods) == 0 {
+		return nil
+	}
+	currentTime := now()
+	for i := range periods {
+		if !currentTime.Before(periods[i].StartTime) && currentTime.Before(periods[i].EndTime) {
+			return &periods[i]
+		}
+	}
+	return &periods[0]
+}
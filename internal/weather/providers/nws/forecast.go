@@ -0,0 +1,95 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"weatherbot/internal/weather"
+)
+
+const cntRows = 18
+
+// now current time. it is replaced in tests
+var now = time.Now
+
+// GetWeatherDataForecast get forecast from data provider
+// hourly forecast has no pressure, clouds and sunrise/sunset, so they are left zero and not shown on the card
+func (n *NWS) GetWeatherDataForecast(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
+	const method = "GetWeatherDataForecast"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	forecast, err := n.getHourlyForecast(ctx, cityInfo)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. %w", method, err)
+		return
+	}
+
+	data := convertForecast(forecast, now(), cntRows)
+	weather.TranslateConditions(data, n.Language)
+	ch <- data
+}
+
+// convertForecast converts at most limit periods from the current hour to forecast data with metric units
+func convertForecast(resp *ForecastResponse, currentTime time.Time, limit int) *weather.ForecastData {
+	data := &weather.ForecastData{}
+	var lastTime time.Time
+	for _, period := range resp.Properties.Periods {
+		if !period.EndTime.After(currentTime) || len(data.Rows) >= limit {
+			continue
+		}
+		temperature := convertTemperature(period.Temperature, period.TemperatureUnit)
+		row := weather.Row{
+			Timestamp:   getLocalTime(period.StartTime),
+			Temperature: temperature,
+			// hourly forecast has no apparent temperature
+			FeelsLike: temperature,
+			Humidity:  percent(period.RelativeHumidity),
+			Weather:   strings.ToLower(period.ShortForecast),
+			Pop:       fmt.Sprintf("%d", percent(period.ProbabilityOfPrecipitation)),
+			Wind: weather.Wind{
+				Speed: parseWindSpeed(period.WindSpeed),
+				Deg:   windDegree(period.WindDirection),
+			},
+		}
+		data.Rows = append(data.Rows, row)
+		lastTime = period.StartTime
+	}
+	if !lastTime.IsZero() {
+		data.Days = math.Ceil(lastTime.Sub(currentTime).Hours() / 24)
+	}
+	return data
+}
+
+// GetDailyForecast returns daily forecast aggregated from hourly forecast (about 7 days)
+// days are grouped in timezone of forecast office
+func (n *NWS) GetDailyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*weather.ForecastData, error) {
+	forecast, err := n.getHourlyForecast(ctx, cityInfo)
+	if err != nil {
+		return nil, fmt.Errorf("GetDailyForecast. %w", err)
+	}
+
+	periods := forecast.Properties.Periods
+	data := convertForecast(forecast, now(), len(periods))
+	var loc *time.Location
+	if len(periods) > 0 {
+		loc = periods[0].StartTime.Location()
+	}
+	data.Daily = weather.DailyFromRows(data.Rows, loc)
+	data.Rows = nil
+	data.Days = float64(len(data.Daily))
+	weather.TranslateConditions(data, n.Language)
+	return data, nil
+}
+
+func getLocalTime(t time.Time) string {
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,66 @@
+package nws
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/handler"
+	"weatherbot/internal/weather/providers/openmeteo"
+)
+
+// Name name of provider in config and crontab
+const Name = "nws"
+
+// userAgentConfig config key with User-Agent. api.weather.gov requires User-Agent with contact info
+const userAgentConfig = "NWS_USER_AGENT"
+
+const defaultUserAgent = "(weatherbot, github.com/smirnov-a/weatherbot)"
+
+const pointsUrl = "https://api.weather.gov/points"
+
+func init() {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name:         Name,
+		Factory:      newProvider,
+		ConfigKeys:   []weather.ConfigKey{{Name: userAgentConfig}},
+		Capabilities: []weather.Capability{weather.CapabilityCurrent, weather.CapabilityForecast, weather.CapabilityDaily},
+	})
+}
+
+// NWS provider of US National Weather Service (api.weather.gov). api key is not required
+// it covers only the USA. coordinates are resolved to gridpoint of forecast office by /points
+type NWS struct {
+	UserAgent string
+	PointsUrl string
+	Cache     *cache.Cache
+	Logger    *logrus.Logger
+	Language  string
+}
+
+func newProvider(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+	userAgent := cfg.Get(userAgentConfig)
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	return &NWS{
+		UserAgent: userAgent,
+		PointsUrl: pointsUrl,
+		Cache:     cfg.Cache,
+		Logger:    cfg.Logger,
+		Language:  cfg.Language,
+	}, nil
+}
+
+func (n *NWS) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return handler.GetWeatherDataImpl(ctx, city, n)
+}
+
+func (n *NWS) GetCacheInstance() *cache.Cache {
+	return n.Cache
+}
+
+// GetGeoCodeCityInfo NWS has no geocoding api, so open-meteo geocoding is used
+func (n *NWS) GetGeoCodeCityInfo(ctx context.Context, city string) (*weather.CityInfo, error) {
+	return openmeteo.GeoCode(ctx, city, n.Logger)
+}
@@ -0,0 +1,159 @@
+package nws
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/providers/providertest"
+)
+
+// testServer serves recorded responses of api.weather.gov from testdata
+// urls of api in responses are replaced with url of test server
+type testServer struct {
+	*httptest.Server
+	points atomic.Int32
+	hourly atomic.Int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	server := &testServer{}
+	serve := func(w http.ResponseWriter, r *http.Request, name string) {
+		if !strings.Contains(r.Header.Get("User-Agent"), "weatherbot") {
+			t.Errorf("request without identifying User-Agent: %q", r.Header.Get("User-Agent"))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		providertest.ServeFixture(t, w, name, "application/geo+json", "https://api.weather.gov", server.URL)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/points/", func(w http.ResponseWriter, r *http.Request) {
+		server.points.Add(1)
+		if r.URL.Path != "/points/38.8951,-77.0364" {
+			http.NotFound(w, r)
+			return
+		}
+		serve(w, r, "points.json")
+	})
+	mux.HandleFunc("/gridpoints/LWX/97,71/forecast/hourly", func(w http.ResponseWriter, r *http.Request) {
+		server.hourly.Add(1)
+		serve(w, r, "forecast_hourly.json")
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetWeatherData(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 7, 1, 10, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	server := newTestServer(t)
+	provider := &NWS{
+		UserAgent: defaultUserAgent,
+		PointsUrl: server.URL + "/points",
+		Cache:     cache.New(cache.NoExpiration, cache.NoExpiration),
+		Logger:    providertest.Logger(),
+	}
+
+	data, err := provider.GetWeatherData(context.Background(), "Washington[38.8951 -77.0364]")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 72°F
+	if data.CurrentData.City != "Washington" || data.CurrentData.Weather != 22 {
+		t.Errorf("unexpected current data %+v", data.CurrentData)
+	}
+
+	rows := data.ForecastData.Rows
+	if len(rows) != cntRows {
+		t.Fatalf("expected %d rows, got %d", cntRows, len(rows))
+	}
+	// the first row is the current hour 06:00 EDT
+	row := rows[0]
+	if row.Timestamp != time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC).Local().Format(time.DateTime) {
+		t.Errorf("unexpected first row time %s", row.Timestamp)
+	}
+	if row.Temperature != 22 || row.Humidity != 78 || row.Weather != "sunny" || row.Pop != "0" {
+		t.Errorf("unexpected values of row %+v", row)
+	}
+	// 5 mph from SW
+	if row.Wind.Speed != 2.2 || row.Wind.Deg != 225 {
+		t.Errorf("unexpected wind of row %+v", row.Wind)
+	}
+	// 88°F, "5 to 10 mph" from SE
+	if row := rows[6]; row.Temperature != 30 || row.Wind.Speed != 4.5 || row.Wind.Deg != 135 || row.Pop != "30" {
+		t.Errorf("unexpected values of row %+v", row)
+	}
+
+	// current weather and forecast of the card share one hourly forecast request
+	if n := server.hourly.Load(); n != 1 {
+		t.Errorf("expected 1 hourly forecast request, got %d", n)
+	}
+
+	// gridpoint of coordinates and hourly forecast are taken from cache
+	if _, err := provider.GetWeatherData(context.Background(), "Washington[38.8951 -77.0364]"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := server.points.Load(); n != 1 {
+		t.Errorf("expected 1 points request, got %d", n)
+	}
+	if n := server.hourly.Load(); n != 1 {
+		t.Errorf("expected cached hourly forecast, got %d requests", n)
+	}
+}
+
+func TestGetDailyForecast(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 7, 1, 10, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	server := newTestServer(t)
+	provider := &NWS{
+		UserAgent: defaultUserAgent,
+		PointsUrl: server.URL + "/points",
+		Cache:     cache.New(cache.NoExpiration, cache.NoExpiration),
+	}
+
+	data, err := provider.GetDailyForecast(context.Background(), &weather.CityInfo{Name: "Washington", Latitude: 38.8951, Longitude: -77.0364, HasCoords: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// periods from 06:00 EDT to 05:00 of the next day
+	if len(data.Daily) != 2 || data.Days != 2 || len(data.Rows) != 0 {
+		t.Fatalf("expected 2 days, got %+v", data.Daily)
+	}
+	day := data.Daily[0]
+	if day.Date != "2024-07-01" || day.TempMin != 22 || day.TempMax != 32 || day.WindMax != 6.7 || day.Pop != "60" {
+		t.Errorf("unexpected values of day %+v", day)
+	}
+	if day.Weather != "clear" {
+		t.Errorf("expected dominant condition clear, got %q", day.Weather)
+	}
+	if day := data.Daily[1]; day.Date != "2024-07-02" || day.TempMin != 22 || day.TempMax != 24 {
+		t.Errorf("unexpected values of day %+v", day)
+	}
+}
+
+func TestParseWindSpeed(t *testing.T) {
+	tests := map[string]float64{
+		"10 mph":       4.5,
+		"5 to 10 mph":  4.5,
+		"0 mph":        0,
+		"18 km/h":      5,
+		"":             0,
+		"calm":         0,
+		"10 to 15 mph": 6.7,
+	}
+	for value, want := range tests {
+		if got := parseWindSpeed(value); got != want {
+			t.Errorf("parseWindSpeed(%q) = %v; want %v", value, got, want)
+		}
+	}
+}
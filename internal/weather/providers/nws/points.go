@@ -0,0 +1,59 @@
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/patrickmn/go-cache"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+// pointsLock locks /points requests by coordinates
+var pointsLock utils.KeyLock
+
+// GridPoint gridpoint of forecast office for coordinates
+type GridPoint struct {
+	Office         string
+	X              int
+	Y              int
+	ForecastHourly string
+}
+
+// getGridPoint resolves coordinates to gridpoint by /points api
+// gridpoints are not changed, so they are cached without expiration next to geocode data
+func (n *NWS) getGridPoint(ctx context.Context, cityInfo *weather.CityInfo) (*GridPoint, error) {
+	if cityInfo == nil || !cityInfo.HasCoords {
+		return nil, fmt.Errorf("no coordinates of city")
+	}
+	// api redirects to the url with at most 4 decimals
+	coords := fmt.Sprintf("%.4f,%.4f", cityInfo.Latitude, cityInfo.Longitude)
+	cacheKey := fmt.Sprintf("points_%s", coords)
+
+	defer pointsLock.Lock(cacheKey)()
+
+	if cacheData, found := n.Cache.Get(cacheKey); found {
+		return cacheData.(*GridPoint), nil
+	}
+
+	body, err := n.fetch(ctx, n.PointsUrl+"/"+coords)
+	if err != nil {
+		return nil, err
+	}
+	var result PointResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error parse points response: %w", err)
+	}
+	if result.Properties.ForecastHourly == "" {
+		return nil, fmt.Errorf("no forecast for point %s", coords)
+	}
+
+	point := &GridPoint{
+		Office:         result.Properties.GridId,
+		X:              result.Properties.GridX,
+		Y:              result.Properties.GridY,
+		ForecastHourly: result.Properties.ForecastHourly,
+	}
+	n.Cache.Set(cacheKey, point, cache.NoExpiration)
+	return point, nil
+}
@@ -0,0 +1,83 @@
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+// fetch returns body of api response. api.weather.gov rejects requests without User-Agent
+func (n *NWS) fetch(ctx context.Context, url string) ([]byte, error) {
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         url,
+		QueryParams: &map[string]string{},
+		Headers: &map[string]string{
+			"User-Agent": n.UserAgent,
+			"Accept":     "application/geo+json",
+		},
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error read response: %w", err)
+	}
+	return body, nil
+}
+
+// hourlyTTL how long hourly forecast of gridpoint is cached. forecast is updated by office about once an hour
+const hourlyTTL = 10 * time.Minute
+
+// hourlyLock locks hourly forecast requests by gridpoint
+var hourlyLock utils.KeyLock
+
+// getHourlyForecast returns hourly forecast of gridpoint of city
+func (n *NWS) getHourlyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*ForecastResponse, error) {
+	point, err := n.getGridPoint(ctx, cityInfo)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := fmt.Sprintf("hourly_%s_%d,%d", point.Office, point.X, point.Y)
+
+	defer hourlyLock.Lock(point.ForecastHourly)()
+
+	if cacheData, found := n.Cache.Get(cacheKey); found {
+		return cacheData.(*ForecastResponse), nil
+	}
+
+	body, err := n.fetch(ctx, point.ForecastHourly)
+	if err != nil {
+		return nil, err
+	}
+	var result ForecastResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error Unmarshal result: %w", err)
+	}
+	n.Cache.Set(cacheKey, &result, hourlyTTL)
+	return &result, nil
+}
+
+// GetUrlParams api uses coordinates in path, so there are no query parameters
+func (n *NWS) GetUrlParams(cityInfo *weather.CityInfo) *map[string]string {
+	return &map[string]string{}
+}
+
+func (n *NWS) GetGeoCodingParams(city string) *map[string]string {
+	return &map[string]string{}
+}
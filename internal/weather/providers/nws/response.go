@@ -0,0 +1,42 @@
+package nws
+
+import "time"
+
+type PointResponse struct {
+	Properties PointProperties `json:"properties"`
+}
+
+type PointProperties struct {
+	GridId         string `json:"gridId"`
+	GridX          int    `json:"gridX"`
+	GridY          int    `json:"gridY"`
+	ForecastHourly string `json:"forecastHourly"`
+	TimeZone       string `json:"timeZone"`
+}
+
+type ForecastResponse struct {
+	Properties ForecastProperties `json:"properties"`
+}
+
+type ForecastProperties struct {
+	Periods []Period `json:"periods"`
+}
+
+type Period struct {
+	StartTime                  time.Time `json:"startTime"`
+	EndTime                    time.Time `json:"endTime"`
+	IsDaytime                  bool      `json:"isDaytime"`
+	Temperature                float64   `json:"temperature"`
+	TemperatureUnit            string    `json:"temperatureUnit"`
+	ProbabilityOfPrecipitation Value     `json:"probabilityOfPrecipitation"`
+	RelativeHumidity           Value     `json:"relativeHumidity"`
+	WindSpeed                  string    `json:"windSpeed"`
+	WindDirection              string    `json:"windDirection"`
+	ShortForecast              string    `json:"shortForecast"`
+}
+
+// Value quantitative value with unit code like "wmoUnit:percent". value is null when unknown
+type Value struct {
+	UnitCode string   `json:"unitCode"`
+	Value    *float64 `json:"value"`
+}
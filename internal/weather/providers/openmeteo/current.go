@@ -0,0 +1,68 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+// GetCurrentWeatherData get current weather from data provider
+func (om *OpenMeteo) GetCurrentWeatherData(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
+	const method = "GetCurrentWeatherData"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	additional := map[string]string{
+		"current": "temperature_2m",
+	}
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         om.ForecastUrl,
+		QueryParams: utils.GetQueryParams(om, cityInfo, &additional),
+	}
+
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error creating request: %w", method, err)
+		return
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error fetching data: %w", method, err)
+		return
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error read response: %w", method, err)
+		return
+	}
+
+	var result CurrentResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+		return
+	}
+
+	data := &weather.CurrentData{
+		City:    cityInfo.Name,
+		Weather: math.Round(result.Current.Temperature2m),
+	}
+
+	ch <- data
+}
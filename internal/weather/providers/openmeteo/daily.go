@@ -0,0 +1,86 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+const cntDailyDays = "10"
+
+const dailyFields = "weather_code,temperature_2m_max,temperature_2m_min,sunrise,sunset," +
+	"precipitation_sum,precipitation_probability_max,wind_speed_10m_max"
+
+// GetDailyForecast returns daily forecast from daily variables of forecast api
+func (om *OpenMeteo) GetDailyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*weather.ForecastData, error) {
+	const method = "GetDailyForecast"
+
+	additional := map[string]string{
+		"daily":         dailyFields,
+		"forecast_days": cntDailyDays,
+	}
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         om.ForecastUrl,
+		QueryParams: utils.GetQueryParams(om, cityInfo, &additional),
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error creating request: %w", method, err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error fetching data: %w", method, err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error read response: %w", method, err)
+	}
+
+	var forecastResponse ForecastResponse
+	if err := json.Unmarshal(body, &forecastResponse); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+
+	data := convertDaily(&forecastResponse)
+	weather.TranslateConditions(data, om.Language)
+	return data, nil
+}
+
+// convertDaily converts daily values to forecast data. dates are in timezone of city
+func convertDaily(resp *ForecastResponse) *weather.ForecastData {
+	data := &weather.ForecastData{
+		Offset: resp.UtcOffsetSeconds,
+	}
+	daily := resp.Daily
+	if len(daily.Sunrise) > 0 && len(daily.Sunset) > 0 {
+		data.Sunrise = getLocalTime(daily.Sunrise[0])
+		data.Sunset = getLocalTime(daily.Sunset[0])
+	}
+
+	loc := time.FixedZone("", int(resp.UtcOffsetSeconds))
+	for i, timestamp := range daily.Time {
+		data.Daily = append(data.Daily, weather.DailyRow{
+			Date:          time.Unix(timestamp, 0).In(loc).Format(time.DateOnly),
+			TempMin:       math.Round(valueAt(daily.Temperature2mMin, i)),
+			TempMax:       math.Round(valueAt(daily.Temperature2mMax, i)),
+			Precipitation: valueAt(daily.PrecipitationSum, i),
+			Weather:       weatherDescription(valueAt(daily.WeatherCode, i)),
+			WindMax:       valueAt(daily.WindSpeed10mMax, i),
+			Pop:           strconv.Itoa(valueAt(daily.PrecipitationProbabilityMax, i)),
+		})
+	}
+	data.Days = float64(len(data.Daily))
+	return data
+}
@@ -0,0 +1,134 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+const cntDays = "2"
+const cntRows = 18
+
+const hourlyFields = "temperature_2m,apparent_temperature,pressure_msl,relative_humidity_2m,weather_code,cloud_cover," +
+	"visibility,precipitation,precipitation_probability,wind_speed_10m,wind_direction_10m,wind_gusts_10m"
+
+// now current time. it is replaced in tests
+var now = time.Now
+
+// GetWeatherDataForecast get forecast from data provider
+func (om *OpenMeteo) GetWeatherDataForecast(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
+	const method = "GetWeatherDataForecast"
+
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("panic in %s: %v", method, r)
+		}
+		wg.Done()
+	}()
+
+	additional := map[string]string{
+		"hourly":        hourlyFields,
+		"daily":         "sunrise,sunset",
+		"forecast_days": cntDays,
+	}
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         om.ForecastUrl,
+		QueryParams: utils.GetQueryParams(om, cityInfo, &additional),
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error creating request: %w", method, err)
+		return
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error fetching data: %w", method, err)
+		return
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error read response: %w", method, err)
+		return
+	}
+
+	var forecastResponse ForecastResponse
+	err = json.Unmarshal(body, &forecastResponse)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+		return
+	}
+
+	data := convertForecast(&forecastResponse, now())
+	weather.TranslateConditions(data, om.Language)
+	ch <- data
+}
+
+// convertForecast converts hourly values from currentTime and the first day sunrise/sunset to forecast data
+func convertForecast(resp *ForecastResponse, currentTime time.Time) *weather.ForecastData {
+	data := &weather.ForecastData{
+		Offset: resp.UtcOffsetSeconds,
+	}
+	if len(resp.Daily.Sunrise) > 0 && len(resp.Daily.Sunset) > 0 {
+		data.Sunrise = getLocalTime(resp.Daily.Sunrise[0])
+		data.Sunset = getLocalTime(resp.Daily.Sunset[0])
+	}
+
+	hourly := resp.Hourly
+	var lastTime time.Time
+	for i, timestamp := range hourly.Time {
+		itemTime := time.Unix(timestamp, 0)
+		// the current hour is kept
+		if itemTime.Add(time.Hour).Before(currentTime) || len(data.Rows) >= cntRows {
+			continue
+		}
+		row := weather.Row{
+			Timestamp:     getLocalTime(timestamp),
+			Temperature:   math.Round(valueAt(hourly.Temperature2m, i)),
+			FeelsLike:     math.Round(valueAt(hourly.ApparentTemperature, i)),
+			Pressure:      valueAt(hourly.PressureMsl, i),
+			Humidity:      valueAt(hourly.RelativeHumidity2m, i),
+			Weather:       weatherDescription(valueAt(hourly.WeatherCode, i)),
+			Clouds:        valueAt(hourly.CloudCover, i),
+			Visibility:    int(valueAt(hourly.Visibility, i)),
+			Precipitation: valueAt(hourly.Precipitation, i),
+			Pop:           strconv.Itoa(valueAt(hourly.PrecipitationProbability, i)),
+			Wind: weather.Wind{
+				Speed: valueAt(hourly.WindSpeed10m, i),
+				Deg:   valueAt(hourly.WindDirection10m, i),
+				Gust:  valueAt(hourly.WindGusts10m, i),
+			},
+		}
+		data.Rows = append(data.Rows, row)
+		lastTime = itemTime
+	}
+	if !lastTime.IsZero() {
+		data.Days = math.Ceil(lastTime.Sub(currentTime).Hours() / 24)
+	}
+	return data
+}
+
+// valueAt returns value of hourly variable. variable may be absent in response
+func valueAt[T int | float64](values []T, i int) T {
+	if i < len(values) {
+		return values[i]
+	}
+	var zero T
+	return zero
+}
+
+func getLocalTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).Format(time.DateTime)
+}
@@ -0,0 +1,63 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+// GeoCode finds city by open-meteo geocoding api. it is used by providers which have no geocoding api
+func GeoCode(ctx context.Context, city string, logger *logrus.Logger) (*weather.CityInfo, error) {
+	om := &OpenMeteo{GeoCodeUrl: geoCodeUrl, Logger: logger}
+	return om.GetGeoCodeCityInfo(ctx, city)
+}
+
+func (om *OpenMeteo) GetGeoCodeCityInfo(ctx context.Context, city string) (*weather.CityInfo, error) {
+	const method = "GetGeoCodeCityInfo"
+
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         om.GeoCodeUrl,
+		QueryParams: om.GetGeoCodingParams(city),
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		om.Logger.Printf("%s. Error reading response body: %v", method, err)
+		return nil, err
+	}
+
+	var result GeoCodingResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		om.Logger.Printf("%s. Error parse response body: %v", method, err)
+		return nil, err
+	}
+
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("%s. city %s not found", method, city)
+	}
+
+	return &weather.CityInfo{
+		Name:      city,
+		Latitude:  result.Results[0].Latitude,
+		Longitude: result.Results[0].Longitude,
+		HasCoords: true,
+	}, nil
+}
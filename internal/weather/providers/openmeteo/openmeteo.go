@@ -0,0 +1,53 @@
+package openmeteo
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/handler"
+)
+
+// Name name of provider in config and crontab
+const Name = "openmeteo"
+
+// api urls. they are fields of provider, so tests can replace them with local server
+const (
+	forecastUrl = "https://api.open-meteo.com/v1/forecast"
+	geoCodeUrl  = "https://geocoding-api.open-meteo.com/v1/search"
+)
+
+func init() {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name:         Name,
+		Factory:      newProvider,
+		Capabilities: []weather.Capability{weather.CapabilityCurrent, weather.CapabilityForecast, weather.CapabilityGeocoding, weather.CapabilityDaily},
+	})
+}
+
+// OpenMeteo provider of open-meteo.com. api key is not required
+type OpenMeteo struct {
+	ForecastUrl string
+	GeoCodeUrl  string
+	Cache       *cache.Cache
+	Logger      *logrus.Logger
+	Language    string
+}
+
+func newProvider(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+	return &OpenMeteo{
+		ForecastUrl: forecastUrl,
+		GeoCodeUrl:  geoCodeUrl,
+		Cache:       cfg.Cache,
+		Logger:      cfg.Logger,
+		Language:    cfg.Language,
+	}, nil
+}
+
+func (om *OpenMeteo) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return handler.GetWeatherDataImpl(ctx, city, om)
+}
+
+func (om *OpenMeteo) GetCacheInstance() *cache.Cache {
+	return om.Cache
+}
@@ -0,0 +1,138 @@
+package openmeteo
+
+import (
+	"context"
+	"github.com/patrickmn/go-cache"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/internal/weather/providers/providertest"
+)
+
+// newTestServer serves recorded responses of open-meteo api from testdata
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	serve := func(w http.ResponseWriter, name string) {
+		providertest.ServeFixture(t, w, name, "application/json")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("latitude") == "" || query.Get("timeformat") != "unixtime" {
+			t.Errorf("unexpected forecast query: %s", r.URL.RawQuery)
+		}
+		if query.Get("current") != "" {
+			serve(w, "current.json")
+			return
+		}
+		if query.Get("forecast_days") == cntDailyDays {
+			serve(w, "daily.json")
+			return
+		}
+		serve(w, "forecast.json")
+	})
+	mux.HandleFunc("/v1/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "Moscow" {
+			serve(w, "geocoding.json")
+			return
+		}
+		serve(w, "geocoding_empty.json")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestProvider(t *testing.T) *OpenMeteo {
+	server := newTestServer(t)
+	return &OpenMeteo{
+		ForecastUrl: server.URL + "/v1/forecast",
+		GeoCodeUrl:  server.URL + "/v1/search",
+		Cache:       cache.New(cache.NoExpiration, cache.NoExpiration),
+		Logger:      providertest.Logger(),
+	}
+}
+
+func TestGetWeatherData(t *testing.T) {
+	// 2024-07-01 12:10 Europe/Moscow
+	current := time.Unix(1719825000, 0)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	data, err := newTestProvider(t).GetWeatherData(context.Background(), "Moscow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data.CurrentData.City != "Moscow" || data.CurrentData.Weather != 22 {
+		t.Errorf("unexpected current data %+v", data.CurrentData)
+	}
+
+	forecast := data.ForecastData
+	if forecast.Sunrise != time.Unix(1719794700, 0).Format(time.DateTime) || forecast.Sunset != time.Unix(1719857640, 0).Format(time.DateTime) {
+		t.Errorf("unexpected sunrise %s and sunset %s", forecast.Sunrise, forecast.Sunset)
+	}
+	if forecast.Offset != 10800 || forecast.Days != 1 {
+		t.Errorf("unexpected offset %d or days %v", forecast.Offset, forecast.Days)
+	}
+	if len(forecast.Rows) != cntRows {
+		t.Fatalf("expected %d rows, got %d", cntRows, len(forecast.Rows))
+	}
+
+	// the first row is the current hour 12:00
+	row := forecast.Rows[0]
+	if row.Timestamp != time.Unix(1719824400, 0).Format(time.DateTime) {
+		t.Errorf("unexpected first row time %s", row.Timestamp)
+	}
+	if row.Temperature != 21 || row.FeelsLike != 20 || row.Pressure != 1011.2 || row.Humidity != 62 {
+		t.Errorf("unexpected values of row %+v", row)
+	}
+	if row.Weather != "slight rain showers" || row.Clouds != 85 || row.Precipitation != 0.9 || row.Pop != "55" {
+		t.Errorf("unexpected weather of row %+v", row)
+	}
+	if row.Wind.Speed != 3.1 || row.Wind.Deg != 284 || row.Wind.Gust != 6.5 {
+		t.Errorf("unexpected wind of row %+v", row.Wind)
+	}
+}
+
+func TestGetDailyForecast(t *testing.T) {
+	provider := newTestProvider(t)
+	cityInfo := &weather.CityInfo{Name: "Moscow", Latitude: 55.75, Longitude: 37.62, HasCoords: true}
+
+	data, err := provider.GetDailyForecast(context.Background(), cityInfo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data.Daily) != 10 || data.Days != 10 || len(data.Rows) != 0 {
+		t.Fatalf("expected 10 days, got %d", len(data.Daily))
+	}
+	day := data.Daily[0]
+	if day.Date != "2024-07-01" || day.TempMin != 14 || day.TempMax != 24 || day.Precipitation != 3.4 {
+		t.Errorf("unexpected values of day %+v", day)
+	}
+	if day.Weather != "slight rain showers" || day.WindMax != 6.5 || day.Pop != "55" {
+		t.Errorf("unexpected weather of day %+v", day)
+	}
+	if data.Daily[9].Date != "2024-07-10" {
+		t.Errorf("unexpected last date %s", data.Daily[9].Date)
+	}
+}
+
+func TestGetGeoCodeCityInfo(t *testing.T) {
+	provider := newTestProvider(t)
+
+	cityInfo, err := provider.GetGeoCodeCityInfo(context.Background(), "Moscow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cityInfo.Latitude != 55.75222 || cityInfo.Longitude != 37.61556 || !cityInfo.HasCoords {
+		t.Errorf("unexpected city info %+v", cityInfo)
+	}
+
+	if _, err := provider.GetGeoCodeCityInfo(context.Background(), "Nowhere"); err == nil {
+		t.Error("expected error for unknown city")
+	}
+}
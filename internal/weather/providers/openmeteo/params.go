@@ -0,0 +1,36 @@
+package openmeteo
+
+import (
+	"fmt"
+	"weatherbot/internal/weather"
+)
+
+const limitParam = "1"
+
+// GetUrlParams returns map with parameters for api call
+// time is requested as unix time, so it is formatted the same way as by other providers
+func (om *OpenMeteo) GetUrlParams(cityInfo *weather.CityInfo) *map[string]string {
+	params := om.getDefaultParams()
+	if cityInfo != nil && cityInfo.HasCoords {
+		params["latitude"] = fmt.Sprintf("%f", cityInfo.Latitude)
+		params["longitude"] = fmt.Sprintf("%f", cityInfo.Longitude)
+	}
+
+	return &params
+}
+
+func (om *OpenMeteo) getDefaultParams() map[string]string {
+	return map[string]string{
+		"timezone":        "auto",
+		"timeformat":      "unixtime",
+		"wind_speed_unit": "ms",
+	}
+}
+
+func (om *OpenMeteo) GetGeoCodingParams(city string) *map[string]string {
+	return &map[string]string{
+		"name":   city,
+		"count":  limitParam,
+		"format": "json",
+	}
+}
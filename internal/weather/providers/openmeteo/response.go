@@ -0,0 +1,58 @@
+package openmeteo
+
+type CurrentResponse struct {
+	Current Current `json:"current"`
+}
+
+type Current struct {
+	Time          int64   `json:"time"`
+	Temperature2m float64 `json:"temperature_2m"`
+}
+
+type ForecastResponse struct {
+	UtcOffsetSeconds int64  `json:"utc_offset_seconds"`
+	Hourly           Hourly `json:"hourly"`
+	Daily            Daily  `json:"daily"`
+}
+
+// Hourly values of forecast. every array has value for each item of Time
+type Hourly struct {
+	Time                     []int64   `json:"time"`
+	Temperature2m            []float64 `json:"temperature_2m"`
+	ApparentTemperature      []float64 `json:"apparent_temperature"`
+	PressureMsl              []float64 `json:"pressure_msl"`
+	RelativeHumidity2m       []int     `json:"relative_humidity_2m"`
+	WeatherCode              []int     `json:"weather_code"`
+	CloudCover               []int     `json:"cloud_cover"`
+	Visibility               []float64 `json:"visibility"`
+	Precipitation            []float64 `json:"precipitation"`
+	PrecipitationProbability []int     `json:"precipitation_probability"`
+	WindSpeed10m             []float64 `json:"wind_speed_10m"`
+	WindDirection10m         []int     `json:"wind_direction_10m"`
+	WindGusts10m             []float64 `json:"wind_gusts_10m"`
+}
+
+// Daily values of forecast. only sunrise and sunset are requested for hourly forecast
+type Daily struct {
+	Time                        []int64   `json:"time"`
+	Sunrise                     []int64   `json:"sunrise"`
+	Sunset                      []int64   `json:"sunset"`
+	WeatherCode                 []int     `json:"weather_code"`
+	Temperature2mMax            []float64 `json:"temperature_2m_max"`
+	Temperature2mMin            []float64 `json:"temperature_2m_min"`
+	PrecipitationSum            []float64 `json:"precipitation_sum"`
+	PrecipitationProbabilityMax []int     `json:"precipitation_probability_max"`
+	WindSpeed10mMax             []float64 `json:"wind_speed_10m_max"`
+}
+
+type GeoCodingResponse struct {
+	Results []GeoCodingResult `json:"results"`
+}
+
+type GeoCodingResult struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Country   string  `json:"country"`
+	Timezone  string  `json:"timezone"`
+}
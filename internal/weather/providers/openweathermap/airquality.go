@@ -0,0 +1,49 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+const airPollutionUrl = "https://api.openweathermap.org/data/2.5/air_pollution"
+
+// GetAirQuality returns current air quality from air pollution api
+func (owm *OpenWeatherMap) GetAirQuality(ctx context.Context, cityInfo *weather.CityInfo) (*weather.AirQuality, error) {
+	const method = "GetAirQuality"
+
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         airPollutionUrl,
+		QueryParams: owm.GetUrlParams(cityInfo),
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error creating request: %w", method, err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error fetching data: %w", method, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error read response: %w", method, err)
+	}
+
+	var result AirPollutionResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("%s. no air quality in response", method)
+	}
+	c := result.List[0].Components
+	return weather.NewAirQuality(c.PM25, c.PM10, c.O3, c.NO2), nil
+}
@@ -0,0 +1,70 @@
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+// oneCallUrl One Call API 3.0. it requires separate subscription
+const oneCallUrl = "https://api.openweathermap.org/data/3.0/onecall"
+
+// GetAlerts returns active alerts for city from One Call API
+// alerts have no ids, so id is built from sender, event and start time
+func (owm *OpenWeatherMap) GetAlerts(ctx context.Context, city string) ([]weather.Alert, error) {
+	const method = "GetAlerts"
+
+	cityInfo, err := utils.GetCityInfo(ctx, city, owm)
+	if err != nil {
+		return nil, err
+	}
+	query := owm.GetUrlParams(cityInfo)
+	(*query)["exclude"] = "current,minutely,hourly,daily"
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         oneCallUrl,
+		QueryParams: query,
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error creating request: %w", method, err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error fetching data: %w", method, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error read response: %w", method, err)
+	}
+
+	var result OneCallResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+
+	alerts := make([]weather.Alert, 0, len(result.Alerts))
+	for _, item := range result.Alerts {
+		alerts = append(alerts, weather.Alert{
+			ID:          weather.AlertID(item.SenderName, item.Event, fmt.Sprint(item.Start)),
+			Provider:    Name,
+			City:        cityInfo.Name,
+			Event:       item.Event,
+			Headline:    item.Event,
+			Description: strings.TrimSpace(item.Description),
+			Sender:      item.SenderName,
+			Start:       time.Unix(item.Start, 0),
+			End:         time.Unix(item.End, 0),
+		})
+	}
+	return alerts, nil
+}
@@ -1,10 +1,10 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"sync"
 	"weatherbot/internal/weather"
@@ -14,7 +14,7 @@ import (
 const weatherUrl = "https://api.openweathermap.org/data/2.5/weather"
 
 // GetCurrentWeatherData get current weather from data provider
-func (owm *OpenWeatherMap) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
+func (owm *OpenWeatherMap) GetCurrentWeatherData(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
 	const method = "GetCurrentWeatherData"
 
 	defer func() {
@@ -25,6 +25,7 @@ func (owm *OpenWeatherMap) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg
 	}()
 
 	params := &utils.RequestParams{
+		Context:     ctx,
 		Method:      http.MethodGet,
 		Url:         weatherUrl,
 		QueryParams: owm.GetUrlParams(cityInfo),
@@ -58,7 +59,7 @@ func (owm *OpenWeatherMap) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg
 
 	wData := 0.0
 	if resMain, found := result["main"]; found {
-		wData = math.Round(resMain.(map[string]interface{})["temp"].(float64))
+		wData = kelvinToCelsius(resMain.(map[string]interface{})["temp"].(float64))
 	}
 	data := &weather.CurrentData{
 		City:    cityInfo.Name,
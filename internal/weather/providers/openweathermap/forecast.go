@@ -1,6 +1,7 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,8 +18,11 @@ const forecastUrl = "https://api.openweathermap.org/data/2.5/forecast"
 // limitOfResult count of items in forecast
 const limitOfResult = "10"
 
+// limitOfDailyResult all items of 5 day forecast with 3-hour step
+const limitOfDailyResult = "40"
+
 // GetWeatherDataForecast get forecast from data provider
-func (owm *OpenWeatherMap) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) { //(data weather.WeatherData, err error) {
+func (owm *OpenWeatherMap) GetWeatherDataForecast(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) { //(data weather.WeatherData, err error) {
 	const method = "GetWeatherDataForecast"
 
 	defer func() {
@@ -28,38 +32,59 @@ func (owm *OpenWeatherMap) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg
 		wg.Done()
 	}()
 
+	data, err := owm.getForecast(ctx, cityInfo, limitOfResult)
+	if err != nil {
+		errCh <- fmt.Errorf("%s. %w", method, err)
+		return
+	}
+
+	ch <- data
+}
+
+// GetDailyForecast returns daily forecast. api has no daily forecast in free plan,
+// so it is aggregated from 5 day forecast with 3-hour step by dates of city timezone
+func (owm *OpenWeatherMap) GetDailyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*weather.ForecastData, error) {
+	data, err := owm.getForecast(ctx, cityInfo, limitOfDailyResult)
+	if err != nil {
+		return nil, fmt.Errorf("GetDailyForecast. %w", err)
+	}
+	data.Daily = weather.DailyFromRows(data.Rows, time.FixedZone("", int(data.Offset)))
+	data.Rows = nil
+	data.Days = float64(len(data.Daily))
+	return data, nil
+}
+
+// getForecast returns forecast with given count of 3-hour items
+func (owm *OpenWeatherMap) getForecast(ctx context.Context, cityInfo *weather.CityInfo, cnt string) (*weather.ForecastData, error) {
 	additional := map[string]string{
-		"cnt": limitOfResult,
+		"cnt": cnt,
 	}
 	params := &utils.RequestParams{
+		Context:     ctx,
 		Method:      http.MethodGet,
 		Url:         forecastUrl,
 		QueryParams: utils.GetQueryParams(owm, cityInfo, &additional),
 	}
 	req, err := utils.NewRequest(params)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error creating request: %w", method, err)
-		return
+		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error fetching data: %w", method, err)
-		return
+		return nil, fmt.Errorf("error fetching data: %w", err)
 	}
 
 	defer response.Body.Close()
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error read response: %w", method, err)
-		return
+		return nil, fmt.Errorf("error read response: %w", err)
 	}
 
 	var weatherResponse WeatherResponse
 	err = json.Unmarshal(body, &weatherResponse)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
-		return
+		return nil, fmt.Errorf("error Unmarshal result: %w", err)
 	}
 
 	offset := weatherResponse.City.Timezone
@@ -76,9 +101,9 @@ func (owm *OpenWeatherMap) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg
 	for _, item := range weatherResponse.List {
 		row := weather.Row{
 			Timestamp:     getLocalTime(item.Dt, offset),
-			Temperature:   math.Round(item.Main.Temp),
-			FeelsLike:     math.Round(item.Main.FeelsLike),
-			Pressure:      convertPaToMmHg(item.Main.Pressure),
+			Temperature:   kelvinToCelsius(item.Main.Temp),
+			FeelsLike:     kelvinToCelsius(item.Main.FeelsLike),
+			Pressure:      item.Main.Pressure,
 			Humidity:      item.Main.Humidity,
 			Weather:       item.Weather[0].Description,
 			Clouds:        item.Clouds.All,
@@ -94,7 +119,7 @@ func (owm *OpenWeatherMap) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg
 		data.Rows = append(data.Rows, row)
 	}
 
-	ch <- data
+	return data, nil
 }
 
 // getPrecipitation try to get Rain first, Snow second
@@ -107,10 +132,9 @@ func getPrecipitation(item ListItem) float64 {
 	return 0
 }
 
-// convertPaToMmHg pressure hPa to mmHg
-func convertPaToMmHg(pressurePa float64) float64 {
-	const pascalToMmHg = 1.33322
-	return math.Round(pressurePa / pascalToMmHg)
+// kelvinToCelsius converts temperature in standard units of api to celsius
+func kelvinToCelsius(kelvin float64) float64 {
+	return math.Round(kelvin - 273.15)
 }
 
 func getLocalTime(timestamp, offset int64) string {
@@ -1,7 +1,9 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"weatherbot/internal/weather"
@@ -10,11 +12,12 @@ import (
 
 const geoCodeUrl = "https://api.openweathermap.org/geo/1.0/direct"
 
-func (owm *OpenWeatherMap) GetGeoCodeCityInfo(city string) (*weather.CityInfo, error) {
+func (owm *OpenWeatherMap) GetGeoCodeCityInfo(ctx context.Context, city string) (*weather.CityInfo, error) {
 	const method = "GetGeoCodeCityInfo"
 	var geoData weather.CityInfo
 
 	params := &utils.RequestParams{
+		Context:     ctx,
 		Method:      http.MethodGet,
 		Url:         geoCodeUrl,
 		QueryParams: owm.GetGeoCodingParams(city),
@@ -25,6 +28,9 @@ func (owm *OpenWeatherMap) GetGeoCodeCityInfo(city string) (*weather.CityInfo, e
 	}
 
 	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, err
+	}
 	defer response.Body.Close()
 
 	body, err := io.ReadAll(response.Body)
@@ -40,6 +46,10 @@ func (owm *OpenWeatherMap) GetGeoCodeCityInfo(city string) (*weather.CityInfo, e
 		return nil, err
 	}
 
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s. city %s not found", method, city)
+	}
+
 	geoData = weather.CityInfo{
 		Name:      city,
 		Latitude:  result[0]["lat"].(float64),
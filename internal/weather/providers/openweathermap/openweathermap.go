@@ -4,19 +4,43 @@ import (
 	"context"
 	"github.com/patrickmn/go-cache"
 	"github.com/sirupsen/logrus"
-	"sync"
 	"weatherbot/internal/weather"
 	"weatherbot/internal/weather/handler"
 )
 
+// Name name of provider in config and crontab
+const Name = "openweathermap"
+
+// apiKeyConfig config key with api key
+const apiKeyConfig = "OPENWEATHERMAP_API_KEY"
+
+func init() {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name:         Name,
+		Factory:      newProvider,
+		ConfigKeys:   []weather.ConfigKey{{Name: apiKeyConfig, Required: true}},
+		Capabilities: []weather.Capability{weather.CapabilityCurrent, weather.CapabilityForecast, weather.CapabilityGeocoding, weather.CapabilityAlerts, weather.CapabilityAirQuality, weather.CapabilityDaily},
+	})
+}
+
 type OpenWeatherMap struct {
-	APIKey string
-	Cache  *cache.Cache
-	Logger *logrus.Logger
+	APIKey   string
+	Cache    *cache.Cache
+	Logger   *logrus.Logger
+	Language string
+}
+
+func newProvider(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+	return &OpenWeatherMap{
+		APIKey:   cfg.Get(apiKeyConfig),
+		Cache:    cfg.Cache,
+		Logger:   cfg.Logger,
+		Language: cfg.Language,
+	}, nil
 }
 
-func (owm *OpenWeatherMap) GetWeatherData(ctx context.Context, city string, ch chan<- *weather.WeatherData, wg *sync.WaitGroup) {
-	handler.GetWeatherDataImpl(ctx, city, owm, ch, wg)
+func (owm *OpenWeatherMap) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return handler.GetWeatherDataImpl(ctx, city, owm)
 }
 
 func (owm *OpenWeatherMap) GetCacheInstance() *cache.Cache {
@@ -20,11 +20,22 @@ func (owm *OpenWeatherMap) GetUrlParams(cityInfo *weather.CityInfo) *map[string]
 	return &params
 }
 
+// languages maps locales to language codes of api
+var languages = map[string]string{
+	"ar": "ar", "bg": "bg", "cs": "cz", "da": "da", "de": "de", "el": "el", "en": "en", "es": "es",
+	"fi": "fi", "fr": "fr", "he": "he", "hi": "hi", "hu": "hu", "it": "it", "ja": "ja", "ko": "kr",
+	"nb": "no", "nl": "nl", "no": "no", "pl": "pl", "pt": "pt", "pt-br": "pt_br", "ro": "ro", "ru": "ru",
+	"sk": "sk", "sv": "se", "tr": "tr", "uk": "ua", "vi": "vi", "zh": "zh_cn", "zh-tw": "zh_tw",
+}
+
 func (owm *OpenWeatherMap) getDefaultParams() map[string]string {
+	lang, found := weather.ProviderLanguage(owm.Language, languages)
+	if !found {
+		lang = "en"
+	}
 	return map[string]string{
 		"appid": owm.APIKey,
-		"units": "metric",
-		"lang":  "ru",
+		"lang":  lang,
 	}
 }
 
@@ -61,3 +61,35 @@ type ListItem struct {
 	} `json:"sys"`
 	DtTxt string `json:"dt_txt"`
 }
+
+// OneCallResponse response of One Call API with alerts only
+type OneCallResponse struct {
+	Alerts []struct {
+		SenderName  string   `json:"sender_name"`
+		Event       string   `json:"event"`
+		Start       int64    `json:"start"`
+		End         int64    `json:"end"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	} `json:"alerts"`
+}
+
+// AirPollutionResponse response of air pollution api. components are in μg/m³
+type AirPollutionResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Aqi int `json:"aqi"`
+		} `json:"main"`
+		Components struct {
+			CO   float64 `json:"co"`
+			NO   float64 `json:"no"`
+			NO2  float64 `json:"no2"`
+			O3   float64 `json:"o3"`
+			SO2  float64 `json:"so2"`
+			PM25 float64 `json:"pm2_5"`
+			PM10 float64 `json:"pm10"`
+			NH3  float64 `json:"nh3"`
+		} `json:"components"`
+	} `json:"list"`
+}
@@ -0,0 +1,36 @@
+// Package providertest contains helpers for tests of weather providers
+package providertest
+
+import (
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ServeFixture writes recorded response from testdata directory of provider package
+// oldnew are pairs of strings replaced in response, e.g. url of api with url of test server
+func ServeFixture(t *testing.T, w http.ResponseWriter, name, contentType string, oldnew ...string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Errorf("fixture %s: %v", name, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if len(oldnew) > 0 {
+		data = []byte(strings.NewReplacer(oldnew...).Replace(string(data)))
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(data)
+}
+
+// Logger returns logger which discards output
+func Logger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
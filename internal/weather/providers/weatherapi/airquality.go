@@ -0,0 +1,29 @@
+package weatherapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"weatherbot/internal/weather"
+)
+
+// GetAirQuality returns air quality from response of current weather api (aqi=yes)
+// the response is shared with current weather, so it is requested once for a card
+func (api *WeatherAPI) GetAirQuality(ctx context.Context, cityInfo *weather.CityInfo) (*weather.AirQuality, error) {
+	const method = "GetAirQuality"
+
+	body, err := api.getCurrent(ctx, cityInfo)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %w", method, err)
+	}
+
+	var result AirQualityResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+	aq := result.Current.AirQuality
+	if aq == nil {
+		return nil, fmt.Errorf("%s. no air quality in response", method)
+	}
+	return weather.NewAirQuality(aq.PM25, aq.PM10, aq.O3, aq.NO2), nil
+}
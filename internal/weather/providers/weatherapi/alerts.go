@@ -0,0 +1,81 @@
+package weatherapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"weatherbot/internal/weather"
+	"weatherbot/utils"
+)
+
+const alertsUrl = "https://api.weatherapi.com/v1/alerts.json"
+
+// msgTypeCancel message type of alert cancellation
+const msgTypeCancel = "Cancel"
+
+// GetAlerts returns active alerts for city
+// weatherapi has no alert ids, so id is built from event, areas and effective time
+func (api *WeatherAPI) GetAlerts(ctx context.Context, city string) ([]weather.Alert, error) {
+	const method = "GetAlerts"
+
+	cityInfo, err := utils.GetCityInfo(ctx, city, api)
+	if err != nil {
+		return nil, err
+	}
+	query := api.GetUrlParams(cityInfo)
+	(*query)["alerts"] = "yes"
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         alertsUrl,
+		QueryParams: query,
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error creating request: %w", method, err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error fetching data: %w", method, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s. error read response: %w", method, err)
+	}
+
+	var result AlertsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+	}
+
+	alerts := make([]weather.Alert, 0, len(result.Alerts.Alert))
+	for _, item := range result.Alerts.Alert {
+		alerts = append(alerts, weather.Alert{
+			ID:          weather.AlertID(item.Event, item.Areas, item.Effective),
+			Provider:    Name,
+			City:        cityInfo.Name,
+			Event:       item.Event,
+			Severity:    item.Severity,
+			Headline:    item.Headline,
+			Description: strings.TrimSpace(item.Desc),
+			Start:       parseAlertTime(item.Effective),
+			End:         parseAlertTime(item.Expires),
+			Cancelled:   item.MsgType == msgTypeCancel,
+		})
+	}
+	return alerts, nil
+}
+
+func parseAlertTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
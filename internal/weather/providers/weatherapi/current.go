@@ -1,12 +1,14 @@
 package weatherapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
 	"sync"
+	"time"
 	"weatherbot/internal/weather"
 	"weatherbot/utils"
 )
@@ -14,7 +16,7 @@ import (
 const weatherUrl = "https://api.weatherapi.com/v1/current.json"
 
 // GetCurrentWeatherData get current weather from data provider
-func (api *WeatherAPI) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
+func (api *WeatherAPI) GetCurrentWeatherData(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.CurrentData, errCh chan<- error) {
 	const method = "GetCurrentWeatherData"
 
 	defer func() {
@@ -24,28 +26,9 @@ func (api *WeatherAPI) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg *syn
 		wg.Done()
 	}()
 
-	params := &utils.RequestParams{
-		Method:      http.MethodGet,
-		Url:         weatherUrl,
-		QueryParams: api.GetUrlParams(cityInfo),
-	}
-
-	req, err := utils.NewRequest(params)
-	if err != nil {
-		errCh <- fmt.Errorf("%s. error creating request: %w", method, err)
-		return
-	}
-
-	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	body, err := api.getCurrent(ctx, cityInfo)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error fetching data: %w", method, err)
-		return
-	}
-
-	defer response.Body.Close()
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		errCh <- fmt.Errorf("%s. error read response: %w", method, err)
+		errCh <- fmt.Errorf("%s. %w", method, err)
 		return
 	}
 
@@ -67,3 +50,46 @@ func (api *WeatherAPI) GetCurrentWeatherData(cityInfo *weather.CityInfo, wg *syn
 
 	ch <- data
 }
+
+// currentTTL how long response of current weather api is cached
+const currentTTL = 5 * time.Minute
+
+// currentLock current weather and air quality of city are taken from the same response
+var currentLock utils.KeyLock
+
+// getCurrent returns body of current weather api response with air quality (aqi=yes)
+func (api *WeatherAPI) getCurrent(ctx context.Context, cityInfo *weather.CityInfo) ([]byte, error) {
+	additional := map[string]string{"aqi": "yes"}
+	query := utils.GetQueryParams(api, cityInfo, &additional)
+	cacheKey := fmt.Sprintf("weatherapi_current_%s_%s", (*query)["q"], (*query)["lang"])
+
+	defer currentLock.Lock(cacheKey)()
+
+	if cacheData, found := api.Cache.Get(cacheKey); found {
+		return cacheData.([]byte), nil
+	}
+
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         weatherUrl,
+		QueryParams: query,
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error read response: %w", err)
+	}
+	api.Cache.Set(cacheKey, body, currentTTL)
+	return body, nil
+}
@@ -1,6 +1,7 @@
 package weatherapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,8 +17,11 @@ const forecastUrl = "https://api.weatherapi.com/v1/forecast.json"
 const cntDays = "2"
 const cntRows = 18
 
+// cntDailyDays days of daily forecast. free plan returns only 3 days
+const cntDailyDays = "10"
+
 // GetWeatherDataForecast get forecast from data provider
-func (api *WeatherAPI) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
+func (api *WeatherAPI) GetWeatherDataForecast(ctx context.Context, cityInfo *weather.CityInfo, wg *sync.WaitGroup, ch chan<- *weather.ForecastData, errCh chan<- error) {
 	const method = "GetWeatherDataForecast"
 
 	defer func() {
@@ -31,40 +35,15 @@ func (api *WeatherAPI) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg *sy
 		"days":        cntDays,
 		"hour_fields": "time,temp_c,feelslike_c,pressure_mb,humidity,wind_kph,condition,cloud,vis_km,precip_mm",
 	}
-	params := &utils.RequestParams{
-		Method:      http.MethodGet,
-		Url:         forecastUrl,
-		QueryParams: utils.GetQueryParams(api, cityInfo, &additional),
-	}
-	req, err := utils.NewRequest(params)
-	if err != nil {
-		errCh <- fmt.Errorf("%s. error creating request: %w", method, err)
-		return
-	}
-
-	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
-	if err != nil {
-		errCh <- fmt.Errorf("%s. error fetching data: %w", method, err)
-		return
-	}
-
-	defer response.Body.Close()
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		errCh <- fmt.Errorf("GetWeatherDataForecast. error read response: %w", err)
-		return
-	}
-
-	var weatherResponse WeatherResponse
-	err = json.Unmarshal(body, &weatherResponse)
+	weatherResponse, err := api.getForecast(ctx, cityInfo, additional)
 	if err != nil {
-		errCh <- fmt.Errorf("%s. error Unmarshal result: %w", method, err)
+		errCh <- fmt.Errorf("%s. %w", method, err)
 		return
 	}
 
 	currentTime := time.Now()
 	data := &weather.ForecastData{
-		Days:    getDayDiff(weatherResponse, currentTime),
+		Days:    getDayDiff(*weatherResponse, currentTime),
 		Sunrise: weatherResponse.Forecast.Forecastday[0].Astro.Sunrise,
 		Sunset:  weatherResponse.Forecast.Forecastday[0].Astro.Sunset,
 	}
@@ -79,7 +58,7 @@ func (api *WeatherAPI) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg *sy
 				Timestamp:     getLocalTime(item.TimeEpoch),
 				Temperature:   math.Round(item.TempC),
 				FeelsLike:     math.Round(item.FeelslikeC),
-				Pressure:      convertPaToMmHg(item.PressureMb),
+				Pressure:      item.PressureMb,
 				Humidity:      item.Humidity,
 				Weather:       item.Condition.Text,
 				Clouds:        item.Cloud,
@@ -98,14 +77,73 @@ func (api *WeatherAPI) GetWeatherDataForecast(cityInfo *weather.CityInfo, wg *sy
 	ch <- data
 }
 
-// convertPaToMmHg pressure hPa to mmHg
-func convertPaToMmHg(pressurePa float64) float64 {
-	const pascalToMmHg = 1.33322
-	return math.Round(pressurePa / pascalToMmHg)
+// GetDailyForecast returns daily forecast from day summaries of forecast api
+func (api *WeatherAPI) GetDailyForecast(ctx context.Context, cityInfo *weather.CityInfo) (*weather.ForecastData, error) {
+	additional := map[string]string{
+		"days": cntDailyDays,
+		// hourly items are not used
+		"hour_fields": "time",
+	}
+	weatherResponse, err := api.getForecast(ctx, cityInfo, additional)
+	if err != nil {
+		return nil, fmt.Errorf("GetDailyForecast. %w", err)
+	}
+
+	days := weatherResponse.Forecast.Forecastday
+	data := &weather.ForecastData{Days: float64(len(days))}
+	if len(days) > 0 {
+		data.Sunrise = days[0].Astro.Sunrise
+		data.Sunset = days[0].Astro.Sunset
+	}
+	for _, day := range days {
+		data.Daily = append(data.Daily, weather.DailyRow{
+			Date:          day.Date,
+			TempMin:       math.Round(day.Day.MinTempC),
+			TempMax:       math.Round(day.Day.MaxTempC),
+			Precipitation: day.Day.TotalPrecipMm,
+			Weather:       day.Day.Condition.Text,
+			WindMax:       kmhToMs(day.Day.MaxWindKph),
+			Pop:           fmt.Sprintf("%d", max(day.Day.DailyChanceOfRain, day.Day.DailyChanceOfSnow)),
+		})
+	}
+	return data, nil
+}
+
+// getForecast requests forecast api with additional parameters
+func (api *WeatherAPI) getForecast(ctx context.Context, cityInfo *weather.CityInfo, additional map[string]string) (*WeatherResponse, error) {
+	params := &utils.RequestParams{
+		Context:     ctx,
+		Method:      http.MethodGet,
+		Url:         forecastUrl,
+		QueryParams: utils.GetQueryParams(api, cityInfo, &additional),
+	}
+	req, err := utils.NewRequest(params)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	response, err := utils.DoRequestWithRetry(req, utils.Retries, utils.RetryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error read response: %w", err)
+	}
+
+	var weatherResponse WeatherResponse
+	err = json.Unmarshal(body, &weatherResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error Unmarshal result: %w", err)
+	}
+	return &weatherResponse, nil
 }
 
+// kmhToMs converts km/h to m/sec rounded to tenths
 func kmhToMs(kmh float64) float64 {
-	return math.Round(kmh / 3.6)
+	return math.Round(kmh/3.6*10) / 10
 }
 
 func getLocalTime(timestamp int64) string {
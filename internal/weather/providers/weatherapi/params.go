@@ -19,12 +19,22 @@ func (api *WeatherAPI) GetUrlParams(cityInfo *weather.CityInfo) *map[string]stri
 	return &params
 }
 
+// languages maps locales to language codes of api. english is default language of api and has no code
+var languages = map[string]string{
+	"ar": "ar", "bg": "bg", "cs": "cs", "da": "da", "de": "de", "el": "el", "es": "es", "fi": "fi",
+	"fr": "fr", "hi": "hi", "hu": "hu", "it": "it", "ja": "ja", "ko": "ko", "nl": "nl", "pl": "pl",
+	"pt": "pt", "ro": "ro", "ru": "ru", "sk": "sk", "sr": "sr", "sv": "sv", "tr": "tr", "uk": "uk",
+	"vi": "vi", "zh": "zh", "zh-tw": "zh_tw",
+}
+
 func (api *WeatherAPI) getDefaultParams() map[string]string {
-	return map[string]string{
-		"key":  api.APIKey,
-		"lang": "ru",
-		"aqi":  "no",
+	params := map[string]string{
+		"key": api.APIKey,
+	}
+	if lang, found := weather.ProviderLanguage(api.Language, languages); found {
+		params["lang"] = lang
 	}
+	return params
 }
 
 func (api *WeatherAPI) GetGeoCodingParams(city string) *map[string]string {
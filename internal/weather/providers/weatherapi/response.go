@@ -137,3 +137,46 @@ type Hour struct {
 	GustMph      float64   `json:"gust_mph"`
 	GustKph      float64   `json:"gust_kph"`
 }
+
+type AlertsResponse struct {
+	Alerts Alerts `json:"alerts"`
+}
+
+type Alerts struct {
+	Alert []AlertItem `json:"alert"`
+}
+
+type AlertItem struct {
+	Headline    string `json:"headline"`
+	MsgType     string `json:"msgtype"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Category    string `json:"category"`
+	Certainty   string `json:"certainty"`
+	Event       string `json:"event"`
+	Note        string `json:"note"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Desc        string `json:"desc"`
+	Instruction string `json:"instruction"`
+}
+
+// AirQualityResponse current weather response with air quality (aqi=yes)
+type AirQualityResponse struct {
+	Current struct {
+		AirQuality *AirQuality `json:"air_quality"`
+	} `json:"current"`
+}
+
+// AirQuality concentrations of pollutants in μg/m³
+type AirQuality struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM25         float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEpaIndex   int     `json:"us-epa-index"`
+	GbDefraIndex int     `json:"gb-defra-index"`
+}
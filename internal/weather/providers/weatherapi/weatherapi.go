@@ -4,19 +4,43 @@ import (
 	"context"
 	"github.com/patrickmn/go-cache"
 	"github.com/sirupsen/logrus"
-	"sync"
 	"weatherbot/internal/weather"
 	"weatherbot/internal/weather/handler"
 )
 
+// Name name of provider in config and crontab
+const Name = "weatherapi"
+
+// apiKeyConfig config key with api key
+const apiKeyConfig = "WEATHERAPI_API_KEY"
+
+func init() {
+	weather.RegisterProvider(weather.ProviderInfo{
+		Name:         Name,
+		Factory:      newProvider,
+		ConfigKeys:   []weather.ConfigKey{{Name: apiKeyConfig, Required: true}},
+		Capabilities: []weather.Capability{weather.CapabilityCurrent, weather.CapabilityForecast, weather.CapabilityGeocoding, weather.CapabilityAlerts, weather.CapabilityAirQuality, weather.CapabilityDaily},
+	})
+}
+
 type WeatherAPI struct {
-	APIKey string
-	Cache  *cache.Cache
-	Logger *logrus.Logger
+	APIKey   string
+	Cache    *cache.Cache
+	Logger   *logrus.Logger
+	Language string
+}
+
+func newProvider(cfg weather.ProviderConfig) (weather.WeatherDataInterface, error) {
+	return &WeatherAPI{
+		APIKey:   cfg.Get(apiKeyConfig),
+		Cache:    cfg.Cache,
+		Logger:   cfg.Logger,
+		Language: cfg.Language,
+	}, nil
 }
 
-func (api *WeatherAPI) GetWeatherData(ctx context.Context, city string, ch chan<- *weather.WeatherData, wg *sync.WaitGroup) {
-	handler.GetWeatherDataImpl(ctx, city, api, ch, wg)
+func (api *WeatherAPI) GetWeatherData(ctx context.Context, city string) (*weather.WeatherData, error) {
+	return handler.GetWeatherDataImpl(ctx, city, api)
 }
 
 func (api *WeatherAPI) GetCacheInstance() *cache.Cache {
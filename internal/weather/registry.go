@@ -0,0 +1,131 @@
+package weather
+
+import (
+	"fmt"
+	"github.com/patrickmn/go-cache"
+	"github.com/sirupsen/logrus"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"weatherbot/config"
+)
+
+// Capability feature supported by weather provider
+type Capability string
+
+const (
+	CapabilityCurrent    Capability = "current"
+	CapabilityForecast   Capability = "forecast"
+	CapabilityGeocoding  Capability = "geocoding"
+	CapabilityAlerts     Capability = "alerts"
+	CapabilityAirQuality Capability = "air_quality"
+	CapabilityDaily      Capability = "daily"
+)
+
+// ConfigKey key of config used by provider
+type ConfigKey struct {
+	Name     string
+	Required bool
+}
+
+// ProviderConfig settings passed to provider factory
+// Values contains config values of provider by its ConfigKeys, Language is locale of task (en, ru, pt-BR)
+type ProviderConfig struct {
+	Values   map[string]string
+	Cache    *cache.Cache
+	Logger   *logrus.Logger
+	Language string
+}
+
+// Get returns config value by key
+func (c ProviderConfig) Get(key string) string {
+	return c.Values[key]
+}
+
+// ProviderFactory creates provider instance
+type ProviderFactory func(cfg ProviderConfig) (WeatherDataInterface, error)
+
+// ProviderInfo description of weather provider in registry
+type ProviderInfo struct {
+	Name         string
+	Factory      ProviderFactory
+	ConfigKeys   []ConfigKey
+	Capabilities []Capability
+}
+
+// Has checks if provider supports capability
+func (p ProviderInfo) Has(capability Capability) bool {
+	return slices.Contains(p.Capabilities, capability)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]ProviderInfo{}
+)
+
+// RegisterProvider adds provider to registry. usually it is called from init()
+// of provider package. package is linked by blank import in providers/all.go
+func RegisterProvider(info ProviderInfo) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if _, found := providers[info.Name]; found {
+		panic(fmt.Sprintf("weather provider %q already registered", info.Name))
+	}
+	providers[info.Name] = info
+}
+
+// GetProvider returns provider info by name
+func GetProvider(name string) (ProviderInfo, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	info, found := providers[name]
+	return info, found
+}
+
+// ProviderNames returns sorted list of registered providers
+func ProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateProvider checks that provider is registered and its required config keys are set
+func ValidateProvider(name string) error {
+	info, found := GetProvider(name)
+	if !found {
+		return fmt.Errorf("unknown weather provider %q (available: %s)", name, strings.Join(ProviderNames(), ", "))
+	}
+	for _, key := range info.ConfigKeys {
+		if key.Required && config.GetConfigValue(key.Name) == "" {
+			return fmt.Errorf("weather provider %s: %s is not set", name, key.Name)
+		}
+	}
+	return nil
+}
+
+// NewProvider creates provider by name with settings from config. texts of provider are in given language
+func NewProvider(name string, cache *cache.Cache, logger *logrus.Logger, language string) (WeatherDataInterface, error) {
+	if err := ValidateProvider(name); err != nil {
+		return nil, err
+	}
+	info, _ := GetProvider(name)
+	cfg := ProviderConfig{
+		Values:   make(map[string]string, len(info.ConfigKeys)),
+		Cache:    cache,
+		Logger:   logger,
+		Language: language,
+	}
+	for _, key := range info.ConfigKeys {
+		cfg.Values[key.Name] = config.GetConfigValue(key.Name)
+	}
+	return info.Factory(cfg)
+}
@@ -1,14 +1,37 @@
 package weather
 
 // WeatherData structure with current weather and forecast
+// Provider is the name of provider which returned data
+// AirQuality is nil if provider does not support it or its request failed
 type WeatherData struct {
 	CurrentData  *CurrentData
 	ForecastData *ForecastData
+	AirQuality   *AirQuality
+	Provider     string
+}
+
+// Options per-task settings from crontab line
+// empty values are filled with global settings from config
+// Providers is ordered chain of providers, the next one is used if previous fails
+type Options struct {
+	Providers []string
+	ChatID    int64
+	Language  string
+	Units     string
+	// Pressure unit of pressure (hpa, mmhg, inhg)
+	Pressure string
+	Template string
+	// Aggregate merge data of all providers of chain instead of fallback
+	Aggregate bool
+	// Daily multi-day forecast instead of hourly rows
+	Daily bool
 }
 
 type CurrentData struct {
 	City    string
 	Weather float64
+	// Spread is min/max of current temperature in aggregation mode
+	Spread *Range
 }
 
 type ForecastData struct {
@@ -17,6 +40,8 @@ type ForecastData struct {
 	Sunrise string
 	Sunset  string
 	Rows    []Row
+	// Daily is set instead of Rows for daily forecast
+	Daily []DailyRow
 }
 
 type Row struct {
@@ -31,6 +56,8 @@ type Row struct {
 	Pop           string
 	Precipitation float64
 	Wind          Wind
+	// Spread is set in aggregation mode, it keeps min/max of values from several providers
+	Spread *Spread
 }
 
 type Wind struct {
@@ -0,0 +1,100 @@
+package units
+
+import (
+	"math"
+)
+
+// unit systems
+const (
+	Metric   = "metric"
+	Imperial = "imperial"
+)
+
+// Systems list of supported unit systems
+var Systems = []string{Metric, Imperial}
+
+// pressure units
+const (
+	HPa  = "hpa"
+	MmHg = "mmhg"
+	InHg = "inhg"
+)
+
+// PressureUnits list of supported pressure units
+var PressureUnits = []string{HPa, MmHg, InHg}
+
+// DefaultPressure returns pressure unit used by system when pressure unit is not set
+func DefaultPressure(system string) string {
+	if system == Imperial {
+		return InHg
+	}
+	return MmHg
+}
+
+// Temperature converts celsius to given system
+func Temperature(celsius float64, system string) float64 {
+	if system == Imperial {
+		return math.Round(celsius*9/5 + 32)
+	}
+	return celsius
+}
+
+// Speed converts m/sec to given system
+func Speed(ms float64, system string) float64 {
+	if system == Imperial {
+		return math.Round(ms*2.23694*10) / 10
+	}
+	return ms
+}
+
+// Precipitation converts mm to given system
+func Precipitation(mm float64, system string) float64 {
+	if system == Imperial {
+		return math.Round(mm/25.4*100) / 100
+	}
+	return mm
+}
+
+// Pressure converts hPa to given pressure unit
+func Pressure(hpa float64, unit string) float64 {
+	switch unit {
+	case MmHg:
+		return math.Round(hpa / 1.33322)
+	case InHg:
+		return math.Round(hpa*0.02953*100) / 100
+	}
+	return math.Round(hpa)
+}
+
+// Label returns label of unit for kind of value (temperature, speed, precipitation)
+func Label(kind, system string) string {
+	switch kind {
+	case "temperature":
+		if system == Imperial {
+			return "°F"
+		}
+		return "°C"
+	case "speed":
+		if system == Imperial {
+			return "mph"
+		}
+		return "m/sec"
+	case "precipitation":
+		if system == Imperial {
+			return "in"
+		}
+		return "mm"
+	}
+	return ""
+}
+
+// PressureLabel returns label of pressure unit
+func PressureLabel(unit string) string {
+	switch unit {
+	case MmHg:
+		return "mmHg"
+	case InHg:
+		return "inHg"
+	}
+	return "hPa"
+}
@@ -0,0 +1,35 @@
+package units
+
+import "testing"
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		system, pressure                  string
+		temperature, speed, precipitation float64
+		pressureValue                     float64
+		label                             string
+	}{
+		{Metric, HPa, 21, 3.1, 0.9, 1011, "hPa"},
+		{Metric, MmHg, 21, 3.1, 0.9, 758, "mmHg"},
+		{Imperial, InHg, 70, 6.9, 0.04, 29.86, "inHg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.system+"/"+tt.pressure, func(t *testing.T) {
+			if v := Temperature(21, tt.system); v != tt.temperature {
+				t.Errorf("expected temperature %v, got %v", tt.temperature, v)
+			}
+			if v := Speed(3.1, tt.system); v != tt.speed {
+				t.Errorf("expected speed %v, got %v", tt.speed, v)
+			}
+			if v := Precipitation(0.9, tt.system); v != tt.precipitation {
+				t.Errorf("expected precipitation %v, got %v", tt.precipitation, v)
+			}
+			if v := Pressure(1011.2, tt.pressure); v != tt.pressureValue {
+				t.Errorf("expected pressure %v, got %v", tt.pressureValue, v)
+			}
+			if v := PressureLabel(tt.pressure); v != tt.label {
+				t.Errorf("expected label %q, got %q", tt.label, v)
+			}
+		})
+	}
+}
@@ -6,19 +6,34 @@ import (
 	"fmt"
 	"github.com/patrickmn/go-cache"
 	"os"
+	"os/signal"
+	"syscall"
 	"weatherbot/config"
 	"weatherbot/i18n"
+	"weatherbot/internal/alerts"
 	"weatherbot/internal/app"
+	"weatherbot/internal/history"
 	"weatherbot/internal/logger"
 	"weatherbot/internal/scheduler"
+	"weatherbot/internal/storage"
 	"weatherbot/internal/telegram"
+	"weatherbot/internal/weather/providers"
 )
 
 const defaultLang = "en"
 
 func init() {
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options]\n", os.Args[0])
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: %s [options] [command]\n", os.Args[0])
+		fmt.Fprintln(out, "Commands:")
+		fmt.Fprintln(out, "  crontab check [-n N] [file]\tcheck crontab file and show next N run times of each task")
+		fmt.Fprintln(out, "  run [-dry-run] [-out dir] <command> [args]\trun command right away, with -dry-run write output to directory instead of Telegram")
+		fmt.Fprintln(out, "  stop\tstop running daemon")
+		fmt.Fprintln(out, "  status\tshow status of running daemon")
+		fmt.Fprintln(out, "  reload\treload crontab of running daemon")
+		fmt.Fprintln(out, "  history [-n N] [-command name] [-status success|failed] [-city name] [-since duration]\tshow history of task runs")
+		fmt.Fprintln(out, "Options:")
 		flag.PrintDefaults()
 	}
 }
@@ -27,6 +42,7 @@ func main() {
 
 	crontabFile := flag.String("crontab", "crontab", "Path to crontab file")
 	help := flag.Bool("help", false, "Show help")
+	daemonMode := flag.Bool("d", false, "Run as daemon in background")
 
 	flag.Parse()
 
@@ -35,14 +51,41 @@ func main() {
 		os.Exit(0)
 	}
 
+	if flag.NArg() > 0 {
+		os.Exit(runSubcommand(flag.Args(), *crontabFile))
+	}
+
 	if err := checkCronTabFile(*crontabFile); os.IsNotExist(err) {
 		fmt.Printf("File %s does not exist\n", *crontabFile)
 		os.Exit(1)
 	}
-	logger.InitLogger()
-	log := logger.Logger()
-
 	config.IniConfig()
+	if err := providers.Validate(); err != nil {
+		fmt.Printf("Wrong weather provider: %v\n", err)
+		os.Exit(1)
+	}
+
+	// signals are handled by scheduler in all its phases, so they never get default action (exit)
+	// they are registered before pid file of daemon is written, so "status" right after start does not kill it
+	signals := make(chan os.Signal, 4)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1)
+
+	if *daemonMode {
+		cntxt, child, err := startDaemon()
+		if err != nil {
+			fmt.Printf("Failed to start daemon: %v\n", err)
+			os.Exit(1)
+		}
+		if child != nil {
+			fmt.Printf("Daemon started. PID: %d\n", child.Pid)
+			return
+		}
+		defer cntxt.Release()
+	}
+
+	// in daemon mode stdout is redirected to daemon log, so write only to log file
+	logger.InitLogger(!*daemonMode)
+	log := logger.Logger()
 	initLocale()
 
 	bot, err := telegram.NewTelegramBot(config.GetTelegramToken())
@@ -50,25 +93,49 @@ func main() {
 		log.Fatalf("Failed to create telegram bot: %v", err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newStore()
 	app := &app.AppContext{
 		TelegramBot: bot,
 		Cache:       cache.New(cache.NoExpiration, cache.NoExpiration),
 		Crontab:     *crontabFile,
 		ChatID:      config.GetTelegramChatId(),
 		Logger:      log,
-		Context:     context.Background(),
+		Context:     ctx,
+		Cancel:      cancel,
+		History:     newHistory(store),
+		Alerts:      newAlerts(store),
 	}
 
-	scheduler.Start(app)
+	scheduler.Start(app, signals)
 }
 
 // initLocale initialize locale
 func initLocale() {
 	lang := config.GetConfigValue("LANGUAGE")
-	if lang != "" {
-		i18n.Initialize(defaultLang, lang)
-		i18n.SetLocale(lang)
+	if lang == "" {
+		lang = defaultLang
 	}
+	i18n.Initialize(defaultLang, lang)
+	i18n.SetLocale(lang)
+}
+
+// newHistory returns history of task runs stored in local database
+func newHistory(store *storage.Store) *history.History {
+	return history.New(store, config.GetConfigInt("HISTORY_MAX_RECORDS"))
+}
+
+// newAlerts returns store of seen weather alerts in local database
+func newAlerts(store *storage.Store) *alerts.Store {
+	return alerts.New(store)
+}
+
+// newStore returns local database. one store is shared by history and alerts,
+// so their transactions are serialized by its mutex instead of waiting for the file lock
+func newStore() *storage.Store {
+	return storage.New(config.GetConfigValue("DB_PATH"))
 }
 
 func checkCronTabFile(f string) error {
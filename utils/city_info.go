@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"github.com/patrickmn/go-cache"
 	"regexp"
@@ -10,35 +11,48 @@ import (
 	"weatherbot/internal/weather"
 )
 
-// GetCityInfo - returns city information like latitude/longitude
-// city in config may be like "Moscow[30.9768 60.3456]" (geolocation in brackets)
-// so it tries to parse coordinates. if no coordinates then get it via api
-func GetCityInfo(city string, geoCoder weather.GeoCoderInterface) (cityInfo *weather.CityInfo, err error) {
-	re := regexp.MustCompile(`^(.*?)(?:\[(\d+\.\d+)\s+(\d+\.\d+)\])?$`)
-	matches := re.FindStringSubmatch(city)
+// cityRe city with optional coordinates in brackets: Moscow[55.7558 37.6176]
+var cityRe = regexp.MustCompile(`^([^\[\]]+?)(?:\[(-?\d+(?:\.\d+)?)\s+(-?\d+(?:\.\d+)?)\])?$`)
+
+// ParseCity - parse city name with optional coordinates in brackets
+// it does not make any api call so coordinates may be absent in result
+func ParseCity(city string) (cityInfo *weather.CityInfo, err error) {
+	matches := cityRe.FindStringSubmatch(strings.TrimSpace(city))
 	if matches == nil {
-		return cityInfo, fmt.Errorf("wrong city format: %s", city)
+		return nil, fmt.Errorf("wrong city format: %s", city)
 	}
 
 	cityName := strings.TrimSpace(matches[1])
 	cityInfo = &weather.CityInfo{
 		Name: cityName,
 	}
-	if len(matches) > 2 && matches[2] != "" && matches[3] != "" {
+	if matches[2] != "" && matches[3] != "" {
 		lat, err := strconv.ParseFloat(matches[2], 64)
-		if err != nil {
+		if err != nil || lat < -90 || lat > 90 {
 			return cityInfo, fmt.Errorf("wrong latitude: %s", matches[2])
 		}
 		lon, err := strconv.ParseFloat(matches[3], 64)
-		if err != nil {
+		if err != nil || lon < -180 || lon > 180 {
 			return cityInfo, fmt.Errorf("wrong longitude: %s", matches[3])
 		}
 		cityInfo.Latitude = lat
 		cityInfo.Longitude = lon
 		cityInfo.HasCoords = true
 	}
+
+	return cityInfo, nil
+}
+
+// GetCityInfo - returns city information like latitude/longitude
+// city in config may be like "Moscow[30.9768 60.3456]" (geolocation in brackets)
+// so it tries to parse coordinates. if no coordinates then get it via api
+func GetCityInfo(ctx context.Context, city string, geoCoder weather.GeoCoderInterface) (cityInfo *weather.CityInfo, err error) {
+	cityInfo, err = ParseCity(city)
+	if err != nil {
+		return cityInfo, err
+	}
 	if !cityInfo.HasCoords {
-		geoData, err := GetGeoCoderData(cityName, geoCoder)
+		geoData, err := GetGeoCoderData(ctx, cityInfo.Name, geoCoder)
 		if err != nil {
 			return cityInfo, err
 		}
@@ -52,12 +66,12 @@ func GetCityInfo(city string, geoCoder weather.GeoCoderInterface) (cityInfo *wea
 
 // GetGeoCoderData - get city geolocation by api
 // and save it to local cache
-func GetGeoCoderData(city string, geoCoder weather.GeoCoderInterface) (cityInfo *weather.CityInfo, err error) {
+func GetGeoCoderData(ctx context.Context, city string, geoCoder weather.GeoCoderInterface) (cityInfo *weather.CityInfo, err error) {
 	cacheKey := fmt.Sprintf("geocode_%s", city)
 	if cacheData, found := geoCoder.GetCacheInstance().Get(cacheKey); found {
 		cityInfo = cacheData.(*weather.CityInfo)
 	} else {
-		cityInfo, err = geoCoder.GetGeoCodeCityInfo(city)
+		cityInfo, err = geoCoder.GetGeoCodeCityInfo(ctx, city)
 		if err != nil {
 			logger.Logger().Print("err:", err)
 			return nil, err
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"github.com/patrickmn/go-cache"
 	"testing"
@@ -9,7 +10,7 @@ import (
 
 type MockGeocoder struct{}
 
-func (m *MockGeocoder) GetGeoCodeCityInfo(city string) (*weather.CityInfo, error) {
+func (m *MockGeocoder) GetGeoCodeCityInfo(ctx context.Context, city string) (*weather.CityInfo, error) {
 	var lat, lon float64
 	var hasCoords bool
 	var err error
@@ -75,7 +76,7 @@ func TestGetCityInfo(t *testing.T) {
 	}
 	for _, tt := range tests {
 		m := &MockGeocoder{}
-		got, err := GetCityInfo(tt.city, m)
+		got, err := GetCityInfo(context.Background(), tt.city, m)
 		if got == nil || *got != tt.want || (err != nil && err.Error() != tt.err.Error()) {
 			t.Errorf("GetCityInfo(%s) = %v, %v; want %v, %v", tt.city, got, err, tt.want, tt.err)
 		}
@@ -25,7 +25,9 @@ type HTTPProxyDialer struct {
 	ProxyURL *url.URL
 }
 
+// RequestParams parameters of http request. request is cancelled when Context is done
 type RequestParams struct {
+	Context     context.Context
 	Method      string
 	Url         string
 	QueryParams *map[string]string
@@ -65,7 +67,11 @@ func NewRequest(params *RequestParams) (*http.Request, error) {
 		return nil, fmt.Errorf("unsupported body type: %T", body)
 	}
 
-	req, err := http.NewRequest(params.Method, u.String(), reqBody)
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, params.Method, u.String(), reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %v", err)
 	}
@@ -165,6 +171,8 @@ func getPassword(proxyURI *url.URL) string {
 	return password
 }
 
+// DoRequestWithRetry sends request and retries it with growing wait on errors
+// 304 Not Modified is successful response for conditional requests (If-Modified-Since)
 func DoRequestWithRetry(req *http.Request, maxRetires int, initialWait time.Duration) (*http.Response, error) {
 	var response *http.Response
 	var err error
@@ -180,7 +188,7 @@ func DoRequestWithRetry(req *http.Request, maxRetires int, initialWait time.Dura
 		}
 
 		response, err = client.Do(req)
-		if err == nil && response.StatusCode == http.StatusOK {
+		if err == nil && (response.StatusCode == http.StatusOK || response.StatusCode == http.StatusNotModified) {
 			return response, nil
 		}
 
@@ -188,7 +196,11 @@ func DoRequestWithRetry(req *http.Request, maxRetires int, initialWait time.Dura
 			response.Body.Close()
 		}
 
-		time.Sleep(wait)
+		select {
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("request cancelled after %d attempts: %w", attempt+1, req.Context().Err())
+		case <-time.After(wait):
+		}
 		wait *= 2
 	}
 
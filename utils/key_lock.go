@@ -0,0 +1,50 @@
+package utils
+
+import "sync"
+
+// KeyLock mutex by key. concurrent callers with the same key run one at a time,
+// so the second one can take data from cache filled by the first one,
+// callers with different keys do not wait for each other.
+// key is removed when its last holder unlocks it, so keys with dates or urls do not pile up
+type KeyLock struct {
+	mu    sync.Mutex
+	locks map[string]*keyLockEntry
+}
+
+type keyLockEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks key and returns function to unlock it
+func (l *KeyLock) Lock(key string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyLockEntry)
+	}
+	entry, found := l.locks[key]
+	if !found {
+		entry = &keyLockEntry{}
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mu.Unlock()
+
+	entry.mu.Lock()
+	return func() {
+		entry.mu.Unlock()
+		l.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// size number of locked keys. it is used in tests
+func (l *KeyLock) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.locks)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyLock(t *testing.T) {
+	var lock KeyLock
+
+	unlockA := lock.Lock("a")
+
+	// other key is not blocked by locked one
+	done := make(chan struct{})
+	go func() {
+		unlockB := lock.Lock("b")
+		unlockB()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock of other key waits")
+	}
+
+	// the same key waits until it is unlocked
+	var wg sync.WaitGroup
+	locked := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		unlock := lock.Lock("a")
+		close(locked)
+		unlock()
+	}()
+	select {
+	case <-locked:
+		t.Fatal("lock of the same key does not wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlockA()
+	wg.Wait()
+
+	if size := lock.size(); size != 0 {
+		t.Errorf("keys are not removed after unlock: %d", size)
+	}
+}